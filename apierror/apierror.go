@@ -2,9 +2,13 @@ package apierror
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
+// ErrCircuitOpen is returned without sending a request when the circuit breaker for the host is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
 // Description: an object containing references to the source of the error
 type ErrorSource struct {
 	// Description: a string indicating which URI query parameter caused the error.
@@ -0,0 +1,125 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// HTTPError is returned for responses with status code >= 400.
+// It unwraps to *ErrorResponse when the body is a JSON:API error document.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Method is the HTTP method of the request
+	Method string
+	// URL is the request URL
+	URL string
+	// TraceID is the value of X-Trace-Id header sent with the request
+	TraceID string
+	// Attempts is the number of attempts made
+	Attempts int
+	// Body is the raw response body
+	Body []byte
+	// Response is the parsed error document, nil if the body was not JSON:API-shaped
+	Response *ErrorResponse
+}
+
+// Error makes HTTPError compatible with the error interface.
+func (e *HTTPError) Error() string {
+	if e.Response != nil {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Response.Error())
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, string(e.Body))
+}
+
+// Unwrap returns the parsed error document.
+func (e *HTTPError) Unwrap() error {
+	if e.Response == nil {
+		return nil
+	}
+	return e.Response
+}
+
+// Errors returns individual errors from the parsed error document.
+func (e *HTTPError) Errors() []Error {
+	if e.Response == nil {
+		return nil
+	}
+	return e.Response.Errors
+}
+
+// Pointers returns JSON pointers of request document fields that caused the errors.
+func (e *HTTPError) Pointers() []string {
+	var pointers []string
+	for _, apiErr := range e.Errors() {
+		if pointer := apiErr.Pointer(); pointer != "" {
+			pointers = append(pointers, pointer)
+		}
+	}
+	return pointers
+}
+
+// Pointer returns JSON pointer to the field that caused the error, empty if not set.
+func (e *Error) Pointer() string {
+	if e.Source == nil || e.Source.Pointer == nil {
+		return ""
+	}
+	return *e.Source.Pointer
+}
+
+// Parameter returns query parameter that caused the error, empty if not set.
+func (e *Error) Parameter() string {
+	if e.Source == nil || e.Source.Parameter == nil {
+		return ""
+	}
+	return *e.Source.Parameter
+}
+
+// AsHTTPError finds HTTPError in the err chain.
+func AsHTTPError(err error) (*HTTPError, bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr, true
+	}
+	return nil, false
+}
+
+// StatusCode returns HTTP status code of the err, 0 if err is not an HTTPError.
+func StatusCode(err error) int {
+	if httpErr, ok := AsHTTPError(err); ok {
+		return httpErr.StatusCode
+	}
+	return 0
+}
+
+// IsStatus reports whether err is an HTTPError with the given status code.
+func IsStatus(err error, statusCode int) bool {
+	return StatusCode(err) == statusCode
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 response.
+func IsConflict(err error) bool {
+	return IsStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether err is a 401 response.
+func IsUnauthorized(err error) bool {
+	return IsStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 response.
+func IsForbidden(err error) bool {
+	return IsStatus(err, http.StatusForbidden)
+}
+
+// IsValidation reports whether err is a 400 or 422 response, i.e. the request document was rejected.
+func IsValidation(err error) bool {
+	code := StatusCode(err)
+	return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+}
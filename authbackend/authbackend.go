@@ -0,0 +1,303 @@
+// Package authbackend implements net/http handlers for Flussonic auth backend callbacks
+// (on_play and on_publish), so a Go service can authorize sessions of a streamer.
+//
+// Flussonic sends session parameters in the query string of a GET request or as
+// a JSON object in the body of a POST request. The handler answers 200 to allow
+// the session and 403 to deny it, session limits are returned in X-AuthDuration,
+// X-Max-Sessions and X-UserId headers.
+package authbackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Action is the kind of session being authorized.
+type Action string
+
+const (
+	// ActionPlay is a request of the on_play auth backend.
+	ActionPlay Action = "play"
+	// ActionPublish is a request of the on_publish auth backend.
+	ActionPublish Action = "publish"
+)
+
+const (
+	// RequestTypeNewSession is sent for the first request of a session.
+	RequestTypeNewSession = "new_session"
+	// RequestTypeUpdateSession is sent for periodic re-authorization of an existing session.
+	RequestTypeUpdateSession = "update_session"
+)
+
+// Response headers understood by Flussonic.
+const (
+	HeaderAuthDuration = "X-AuthDuration"
+	HeaderMaxSessions  = "X-Max-Sessions"
+	HeaderUserID       = "X-UserId"
+)
+
+// maxBodySize limits the size of a POST request body.
+const maxBodySize = 1 << 20
+
+// Request is an authorization request sent by Flussonic.
+type Request struct {
+	// Action is the kind of the backend the request was received by
+	Action Action
+	// Name is the stream or VOD file name
+	Name string
+	// IP is the address of the client
+	IP string
+	// Token is the authorization token provided by the client
+	Token string
+	// Proto is the protocol of the session, e.g. hls, dash, rtmp, webrtc
+	Proto string
+	// SessionID is unique for a session and the same in all its requests
+	SessionID string
+	// RequestType is RequestTypeNewSession or RequestTypeUpdateSession
+	RequestType string
+	// RequestNumber counts auth requests of the session starting from 0
+	RequestNumber int
+	// DVR is set if the session plays the archive
+	DVR bool
+	// Duration is the duration of an updated session
+	Duration time.Duration
+	// Bytes is the number of bytes passed in the session
+	Bytes int64
+	// StreamClients is the number of clients of the stream
+	StreamClients int
+	// TotalClients is the number of clients of the server
+	TotalClients int
+	// Host is the Host header of the client request
+	Host string
+	// Referer is the Referer header of the client request
+	Referer string
+	// UserAgent is the user agent of the client
+	UserAgent string
+	// Qs is the query string of the client request
+	Qs string
+	// Params contains all received parameters including unknown ones
+	Params map[string]string
+}
+
+// Response is the decision about a session.
+type Response struct {
+	// Allowed lets the session start or continue
+	Allowed bool
+	// Duration is the time until the session is authorized again, Flussonic default if zero
+	Duration time.Duration
+	// MaxSessions limits the number of sessions of the user, no limit if zero
+	MaxSessions int
+	// UserID identifies the user for session limits and statistics
+	UserID string
+}
+
+// Allow returns a Response allowing the session.
+func Allow() *Response {
+	return &Response{Allowed: true}
+}
+
+// Deny returns a Response denying the session.
+func Deny() *Response {
+	return &Response{}
+}
+
+// WithDuration sets the time until the session is authorized again.
+func (r *Response) WithDuration(duration time.Duration) *Response {
+	r.Duration = duration
+	return r
+}
+
+// WithMaxSessions sets the maximum number of sessions of the user.
+func (r *Response) WithMaxSessions(maxSessions int) *Response {
+	r.MaxSessions = maxSessions
+	return r
+}
+
+// WithUserID sets the user of the session.
+func (r *Response) WithUserID(userID string) *Response {
+	r.UserID = userID
+	return r
+}
+
+// AuthorizeFunc decides about a session. Returning an error responds
+// with 500, so Flussonic applies its own policy for backend failures.
+type AuthorizeFunc func(ctx context.Context, request *Request) (*Response, error)
+
+// Handler is an http.Handler calling AuthorizeFunc for every request.
+type Handler struct {
+	action    Action
+	authorize AuthorizeFunc
+	logger    *slog.Logger
+}
+
+// PlayHandler returns a handler of on_play auth backend requests.
+func PlayHandler(authorize AuthorizeFunc) *Handler {
+	return &Handler{action: ActionPlay, authorize: authorize}
+}
+
+// PublishHandler returns a handler of on_publish auth backend requests.
+func PublishHandler(authorize AuthorizeFunc) *Handler {
+	return &Handler{action: ActionPublish, authorize: authorize}
+}
+
+// WithLogger sets the logger of malformed requests and authorization errors.
+func (h *Handler) WithLogger(logger *slog.Logger) *Handler {
+	h.logger = logger
+	return h
+}
+
+// ServeHTTP parses the request, calls AuthorizeFunc and writes its decision.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request, err := ParseRequest(r, h.action)
+	if err != nil {
+		h.log(r.Context(), "invalid auth backend request", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.authorize(r.Context(), request)
+	if err != nil {
+		h.log(r.Context(), "auth backend failed", err)
+		http.Error(w, "authorization failed", http.StatusInternalServerError)
+		return
+	}
+	if response == nil {
+		response = Deny()
+	}
+	response.Write(w)
+}
+
+func (h *Handler) log(ctx context.Context, msg string, err error) {
+	if h.logger != nil {
+		h.logger.WarnContext(ctx, msg, slog.String("action", string(h.action)), slog.Any("error", err))
+	}
+}
+
+// Write writes the decision with session headers to w.
+func (r *Response) Write(w http.ResponseWriter) {
+	if r.Duration > 0 {
+		w.Header().Set(HeaderAuthDuration, strconv.FormatInt(int64(r.Duration/time.Second), 10))
+	}
+	if r.MaxSessions > 0 {
+		w.Header().Set(HeaderMaxSessions, strconv.Itoa(r.MaxSessions))
+	}
+	if r.UserID != "" {
+		w.Header().Set(HeaderUserID, r.UserID)
+	}
+	if r.Allowed {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusForbidden)
+	}
+}
+
+// ParseRequest parses an auth backend request from query parameters of a GET request
+// or a JSON object in the body of a POST request.
+func ParseRequest(r *http.Request, action Action) (*Request, error) {
+	params := map[string]string{}
+	for key, values := range r.URL.Query() {
+		params[key] = values[0]
+	}
+
+	if r.Method == http.MethodPost {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/json" {
+			var body map[string]any
+			if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&body); err != nil {
+				return nil, fmt.Errorf("failed to decode request body: %w", err)
+			}
+			for key, value := range body {
+				params[key] = jsonString(value)
+			}
+		} else {
+			if err := r.ParseForm(); err != nil {
+				return nil, fmt.Errorf("failed to parse request body: %w", err)
+			}
+			for key, values := range r.PostForm {
+				params[key] = values[0]
+			}
+		}
+	}
+
+	request := &Request{
+		Action:      action,
+		Name:        params["name"],
+		IP:          params["ip"],
+		Token:       params["token"],
+		Proto:       params["proto"],
+		SessionID:   params["session_id"],
+		RequestType: params["request_type"],
+		Host:        params["host"],
+		Referer:     params["referer"],
+		UserAgent:   params["user_agent"],
+		Qs:          params["qs"],
+		Params:      params,
+	}
+	if request.Name == "" {
+		return nil, fmt.Errorf("parameter 'name' is required")
+	}
+
+	var err error
+	if request.RequestNumber, err = intParam(params, "request_number"); err != nil {
+		return nil, err
+	}
+	if request.StreamClients, err = intParam(params, "stream_clients"); err != nil {
+		return nil, err
+	}
+	if request.TotalClients, err = intParam(params, "total_clients"); err != nil {
+		return nil, err
+	}
+	duration, err := intParam(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+	request.Duration = time.Duration(duration) * time.Second
+	bytes, err := intParam(params, "bytes")
+	if err != nil {
+		return nil, err
+	}
+	request.Bytes = int64(bytes)
+	if value, ok := params["dvr"]; ok && value != "" {
+		if request.DVR, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid parameter 'dvr': %w", err)
+		}
+	}
+	return request, nil
+}
+
+// intParam parses an optional integer parameter.
+func intParam(params map[string]string, name string) (int, error) {
+	value, ok := params[name]
+	if !ok || value == "" {
+		return 0, nil
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid parameter '%s': %w", name, err)
+	}
+	return result, nil
+}
+
+// jsonString formats a decoded JSON value the way it would be sent in a query string.
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
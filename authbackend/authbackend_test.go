@@ -0,0 +1,146 @@
+package authbackend_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/authbackend"
+)
+
+// loadFixture reads a recorded HTTP request from testdata.
+func loadFixture(t *testing.T, name string) *http.Request {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	request, err := http.ReadRequest(bufio.NewReader(file))
+	require.NoError(t, err)
+	recorded := httptest.NewRequest(request.Method, request.URL.String(), request.Body)
+	recorded.Header = request.Header
+	return recorded
+}
+
+// serveFixture sends a recorded request to handler and returns the response.
+func serveFixture(t *testing.T, handler http.Handler, name string) *http.Response {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, loadFixture(t, name))
+	return recorder.Result()
+}
+
+func TestPlayHandler_NewSession(t *testing.T) {
+	t.Parallel()
+	var received *authbackend.Request
+	handler := authbackend.PlayHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		received = request
+		return authbackend.Allow().WithDuration(5 * time.Minute).WithMaxSessions(2).WithUserID("user-1"), nil
+	})
+
+	response := serveFixture(t, handler, "play_new_session.http")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "300", response.Header.Get(authbackend.HeaderAuthDuration))
+	require.Equal(t, "2", response.Header.Get(authbackend.HeaderMaxSessions))
+	require.Equal(t, "user-1", response.Header.Get(authbackend.HeaderUserID))
+
+	require.Equal(t, authbackend.ActionPlay, received.Action)
+	require.Equal(t, "mylive/bunny", received.Name)
+	require.Equal(t, "203.0.113.7", received.IP)
+	require.Equal(t, "abc123", received.Token)
+	require.Equal(t, "hls", received.Proto)
+	require.Equal(t, "1700000000-a1b2c3", received.SessionID)
+	require.Equal(t, authbackend.RequestTypeNewSession, received.RequestType)
+	require.Equal(t, 0, received.RequestNumber)
+	require.False(t, received.DVR)
+	require.Equal(t, 3, received.StreamClients)
+	require.Equal(t, 42, received.TotalClients)
+	require.Equal(t, "streamer.example.com", received.Host)
+	require.Equal(t, "https://portal.example.com/watch", received.Referer)
+	require.Equal(t, "Mozilla/5.0", received.UserAgent)
+	require.Equal(t, "token=abc123", received.Qs)
+}
+
+func TestPlayHandler_UpdateSession(t *testing.T) {
+	t.Parallel()
+	var received *authbackend.Request
+	handler := authbackend.PlayHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		received = request
+		return authbackend.Deny(), nil
+	})
+
+	response := serveFixture(t, handler, "play_update_session.http")
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+	require.Empty(t, response.Header.Get(authbackend.HeaderAuthDuration))
+
+	require.Equal(t, authbackend.RequestTypeUpdateSession, received.RequestType)
+	require.Equal(t, 3, received.RequestNumber)
+	require.True(t, received.DVR)
+	require.Equal(t, 3*time.Minute, received.Duration)
+	require.Equal(t, int64(73400320), received.Bytes)
+}
+
+func TestPublishHandler_JSON(t *testing.T) {
+	t.Parallel()
+	var received *authbackend.Request
+	handler := authbackend.PublishHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		received = request
+		return authbackend.Allow(), nil
+	})
+
+	response := serveFixture(t, handler, "publish_json.http")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	require.Equal(t, authbackend.ActionPublish, received.Action)
+	require.Equal(t, "cam1", received.Name)
+	require.Equal(t, "198.51.100.20", received.IP)
+	require.Equal(t, "pub-secret", received.Token)
+	require.Equal(t, "rtmp", received.Proto)
+	require.Equal(t, "1700000100-d4e5f6", received.SessionID)
+	require.Equal(t, 17, received.TotalClients)
+	require.Equal(t, `{"client":"obs"}`, received.Params["extra"])
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+	called := false
+	handler := authbackend.PlayHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		called = true
+		return authbackend.Allow(), nil
+	})
+	response := serveFixture(t, handler, "play_missing_name.http")
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	require.False(t, called)
+
+	handler = authbackend.PlayHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		return nil, errors.New("database is down")
+	})
+	response = serveFixture(t, handler, "play_new_session.http")
+	require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+
+	handler = authbackend.PlayHandler(func(ctx context.Context, request *authbackend.Request) (*authbackend.Response, error) {
+		return nil, nil
+	})
+	response = serveFixture(t, handler, "play_new_session.http")
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestParseRequest_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, target := range []string{
+		"/auth?name=a&request_number=x",
+		"/auth?name=a&dvr=maybe",
+		"/auth?name=a&duration=1.5",
+	} {
+		_, err := authbackend.ParseRequest(httptest.NewRequest(http.MethodGet, target, http.NoBody), authbackend.ActionPlay)
+		require.Error(t, err, target)
+	}
+}
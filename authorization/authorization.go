@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,6 +20,8 @@ type AuthKey interface {
 // ClusterKey implements authorization in Cluster timestamp:hash format
 type clusterKey struct {
 	key string
+	// offset is the learned difference between server and local clock in nanoseconds
+	offset atomic.Int64
 }
 
 // ClusterKey creates a new AuthKey with ClusterKey authorization
@@ -27,15 +30,11 @@ func ClusterKey(key string) AuthKey {
 }
 
 // ToHeader generates authorization header value in Cluster format
+// The timestamp is taken from the local clock adjusted with the offset learned by AdjustClock.
 // nolint:gosec
 func (c *clusterKey) ToHeader() string {
-	unixTime := time.Now().Unix()
-	s := fmt.Appendf(nil, "%s:%s", strconv.FormatInt(unixTime, 10), c.key)
-
-	hasher := sha1.New()
-	hasher.Write(s)
-
-	return fmt.Sprintf("Cluster %d:%s", unixTime, hex.EncodeToString(hasher.Sum(nil)))
+	unixTime := time.Now().Add(time.Duration(c.offset.Load())).Unix()
+	return fmt.Sprintf("Cluster %d:%s", unixTime, clusterKeyHash(unixTime, c.key))
 }
 
 // basicAuth implements Basic authorization
@@ -1,14 +1,18 @@
 package authorization_test
 
 import (
+	"context"
 	// nolint:gosec
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -399,3 +403,158 @@ func TestAuthKey_Interface(t *testing.T) {
 		checkAuthKey(t, authorization.BearerAuth("token"))
 	})
 }
+
+func TestRefreshable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("obtains token on first use", func(t *testing.T) {
+		t.Parallel()
+		auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+			return "token", nil
+		})
+		assert.Empty(t, auth.ToHeader())
+
+		header, err := auth.HeaderContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", header)
+		assert.Equal(t, "Bearer token", auth.ToHeader())
+	})
+
+	t.Run("refreshes rejected token once", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		auth := authorization.RefreshableScheme("Token", func(ctx context.Context) (string, error) {
+			n := calls.Add(1)
+			if n > 1 {
+				<-release
+			}
+			return fmt.Sprintf("t%d", n), nil
+		})
+		rejected, err := auth.HeaderContext(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "Token t1", rejected)
+
+		var wg sync.WaitGroup
+		headers := make([]string, 10)
+		for i := range headers {
+			wg.Go(func() {
+				header, err := auth.Refresh(context.Background(), rejected)
+				assert.NoError(t, err)
+				headers[i] = header
+			})
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		for _, header := range headers {
+			assert.Equal(t, "Token t2", header)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+
+		// A stale rejection returns the current token
+		header, err := auth.Refresh(context.Background(), rejected)
+		require.NoError(t, err)
+		assert.Equal(t, "Token t2", header)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("keeps token on failed refresh", func(t *testing.T) {
+		t.Parallel()
+		var fail atomic.Bool
+		auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+			if fail.Load() {
+				return "", errors.New("login failed")
+			}
+			return "token", nil
+		})
+		header, err := auth.HeaderContext(context.Background())
+		require.NoError(t, err)
+
+		fail.Store(true)
+		_, err = auth.Refresh(context.Background(), header)
+		require.ErrorContains(t, err, "login failed")
+		assert.Equal(t, "Bearer token", auth.ToHeader())
+	})
+
+	t.Run("waiting is canceled with context", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		defer close(release)
+		auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+			<-release
+			return "token", nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := auth.HeaderContext(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// clusterHeader returns a Cluster authorization header signed at unixTime
+func clusterHeader(unixTime int64, key string) string {
+	// nolint:gosec
+	hasher := sha1.New()
+	hasher.Write(fmt.Appendf(nil, "%d:%s", unixTime, key))
+	return fmt.Sprintf("Cluster %d:%s", unixTime, hex.EncodeToString(hasher.Sum(nil)))
+}
+
+func TestClusterKey_AdjustClock(t *testing.T) {
+	t.Parallel()
+	auth := authorization.ClusterKey("key")
+	adjuster, ok := auth.(authorization.ClockAdjuster)
+	require.True(t, ok, "ClusterKey should implement ClockAdjuster")
+
+	now := time.Now()
+	assert.False(t, adjuster.AdjustClock(now.Add(500*time.Millisecond), now), "subsecond difference should be ignored")
+	assert.True(t, adjuster.AdjustClock(now.Add(-time.Hour), now))
+	assert.False(t, adjuster.AdjustClock(now.Add(-time.Hour), now), "unchanged offset should not be reported")
+
+	// Header is signed with the server time
+	header := auth.ToHeader()
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(header, ":")[0], "Cluster "), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, now.Add(-time.Hour).Unix(), timestamp, 2)
+	assert.Equal(t, clusterHeader(timestamp, "key"), header)
+}
+
+func TestVerifyClusterKey(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Unix()
+
+	t.Run("accepts headers generated by ClusterKey", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, authorization.VerifyClusterKey(authorization.ClusterKey("key").ToHeader(), "key", 0))
+	})
+
+	t.Run("accepts timestamps within window", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, authorization.VerifyClusterKey(clusterHeader(now-50, "key"), "key", time.Minute))
+		require.NoError(t, authorization.VerifyClusterKey(clusterHeader(now+50, "key"), "key", time.Minute))
+		require.NoError(t, authorization.VerifyClusterKey(clusterHeader(now-240, "key"), "key", 0))
+	})
+
+	t.Run("rejects timestamps outside of window", func(t *testing.T) {
+		t.Parallel()
+		require.ErrorIs(t, authorization.VerifyClusterKey(clusterHeader(now-120, "key"), "key", time.Minute), authorization.ErrClusterKeyExpired)
+		require.ErrorIs(t, authorization.VerifyClusterKey(clusterHeader(now+120, "key"), "key", time.Minute), authorization.ErrClusterKeyExpired)
+		require.ErrorIs(t, authorization.VerifyClusterKey(clusterHeader(now-600, "key"), "key", 0), authorization.ErrClusterKeyExpired)
+	})
+
+	t.Run("rejects invalid headers", func(t *testing.T) {
+		t.Parallel()
+		for _, header := range []string{
+			"",
+			"Bearer token",
+			"Cluster",
+			"Cluster 123",
+			"Cluster abc:def",
+			clusterHeader(now, "other"),
+			strings.Replace(clusterHeader(now, "key"), "Cluster ", "Cluster 1", 1),
+		} {
+			require.ErrorIs(t, authorization.VerifyClusterKey(header, "key", 0), authorization.ErrInvalidClusterKey, header)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package authorization
+
+import (
+	// nolint:gosec
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultClusterKeyWindow is the maximum clock difference accepted by VerifyClusterKey if window is zero.
+const DefaultClusterKeyWindow = 5 * time.Minute
+
+var (
+	// ErrInvalidClusterKey is returned by VerifyClusterKey if the header is malformed or the hash does not match.
+	ErrInvalidClusterKey = errors.New("invalid cluster key")
+	// ErrClusterKeyExpired is returned by VerifyClusterKey if the timestamp is outside of the window.
+	ErrClusterKeyExpired = errors.New("cluster key timestamp is outside of the allowed window")
+)
+
+// ClockAdjuster is implemented by AuthKeys signing the current time, so they can
+// follow the server clock instead of the local one.
+type ClockAdjuster interface {
+	// AdjustClock learns the server clock offset from serverTime observed at localTime
+	// (e.g. from the Date response header) and reports whether signed timestamps changed.
+	AdjustClock(serverTime, localTime time.Time) bool
+}
+
+// AdjustClock sets the offset applied to timestamps of the cluster key.
+// The Date header has a second precision, so differences below a second are ignored.
+func (c *clusterKey) AdjustClock(serverTime, localTime time.Time) bool {
+	offset := serverTime.Sub(localTime)
+	previous := time.Duration(c.offset.Load())
+	if (offset - previous).Abs() <= time.Second {
+		return false
+	}
+	c.offset.Store(int64(offset.Truncate(time.Second)))
+	return true
+}
+
+// VerifyClusterKey checks an Authorization header value in Cluster timestamp:hash format
+// signed with key. The timestamp must differ from the current time by at most window,
+// DefaultClusterKeyWindow is used if window is zero.
+func VerifyClusterKey(header, key string, window time.Duration) error {
+	if window == 0 {
+		window = DefaultClusterKeyWindow
+	}
+
+	scheme, credentials, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Cluster") {
+		return fmt.Errorf("%w: expected Cluster authorization", ErrInvalidClusterKey)
+	}
+	timestamp, hash, ok := strings.Cut(credentials, ":")
+	if !ok {
+		return fmt.Errorf("%w: expected timestamp:hash", ErrInvalidClusterKey)
+	}
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp %q", ErrInvalidClusterKey, timestamp)
+	}
+
+	expected := clusterKeyHash(unixTime, key)
+	if subtle.ConstantTimeCompare([]byte(strings.ToLower(hash)), []byte(expected)) != 1 {
+		return fmt.Errorf("%w: hash mismatch", ErrInvalidClusterKey)
+	}
+
+	if skew := time.Since(time.Unix(unixTime, 0)).Abs(); skew > window {
+		return fmt.Errorf("%w: clock differs by %s", ErrClusterKeyExpired, skew.Truncate(time.Second))
+	}
+	return nil
+}
+
+// clusterKeyHash returns hex encoded SHA-1 of timestamp:key.
+// nolint:gosec
+func clusterKeyHash(unixTime int64, key string) string {
+	hasher := sha1.New()
+	hasher.Write(fmt.Appendf(nil, "%s:%s", strconv.FormatInt(unixTime, 10), key))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
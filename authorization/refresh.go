@@ -0,0 +1,110 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RefreshableAuthKey represents authorization with credentials obtained from a server,
+// e.g. a session token, which can expire and be requested again.
+type RefreshableAuthKey interface {
+	AuthKey
+	// HeaderContext returns Authorization header value, obtaining credentials on first use
+	HeaderContext(ctx context.Context) (string, error)
+	// Refresh obtains new credentials after the server rejected the rejected header value
+	// and returns the new header value
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// TokenFunc obtains a new token, e.g. by logging in.
+type TokenFunc func(ctx context.Context) (string, error)
+
+// refreshableAuth implements RefreshableAuthKey caching the token returned by fetch.
+// Concurrent refreshes share a single fetch call.
+type refreshableAuth struct {
+	scheme string
+	fetch  TokenFunc
+
+	mu       sync.Mutex
+	header   string
+	inflight *refreshCall
+}
+
+// refreshCall is a fetch in progress awaited by all callers refreshing the same token.
+type refreshCall struct {
+	done   chan struct{}
+	header string
+	err    error
+}
+
+// Refreshable creates a new RefreshableAuthKey with Bearer authorization using the token returned by fetch.
+func Refreshable(fetch TokenFunc) RefreshableAuthKey {
+	return RefreshableScheme("Bearer", fetch)
+}
+
+// RefreshableScheme creates a new RefreshableAuthKey with the given authorization scheme.
+func RefreshableScheme(scheme string, fetch TokenFunc) RefreshableAuthKey {
+	return &refreshableAuth{scheme: scheme, fetch: fetch}
+}
+
+// ToHeader returns the cached Authorization header value, empty until the token is obtained.
+func (r *refreshableAuth) ToHeader() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.header
+}
+
+// HeaderContext returns the cached Authorization header value or obtains a new token.
+func (r *refreshableAuth) HeaderContext(ctx context.Context) (string, error) {
+	if header := r.ToHeader(); header != "" {
+		return header, nil
+	}
+	return r.Refresh(ctx, "")
+}
+
+// Refresh obtains a new token unless another caller has already replaced the rejected one.
+// The fetch is not canceled with ctx, so a caller giving up does not fail other waiting callers.
+func (r *refreshableAuth) Refresh(ctx context.Context, rejected string) (string, error) {
+	r.mu.Lock()
+	if r.header != rejected {
+		header := r.header
+		r.mu.Unlock()
+		return header, nil
+	}
+	call := r.inflight
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		r.inflight = call
+		go r.run(context.WithoutCancel(ctx), call)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.header, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// run fetches a new token and completes call.
+func (r *refreshableAuth) run(ctx context.Context, call *refreshCall) {
+	token, err := r.fetch(ctx)
+	switch {
+	case err != nil:
+		call.err = fmt.Errorf("failed to obtain token: %w", err)
+	case token == "":
+		call.err = fmt.Errorf("failed to obtain token: empty token")
+	default:
+		call.header = fmt.Sprintf("%s %s", r.scheme, token)
+	}
+
+	r.mu.Lock()
+	if call.err == nil {
+		r.header = call.header
+	}
+	r.inflight = nil
+	r.mu.Unlock()
+	close(call.done)
+}
@@ -5,7 +5,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"iter"
 	"net/http"
 	"net/url"
@@ -17,6 +19,7 @@ import (
 	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
 	"github.com/flussonic/go-flussonic/internal/cursors"
+	"github.com/flussonic/go-flussonic/internal/urlpath"
 )
 
 // Central defines the interface for interacting with Central API.
@@ -99,9 +102,17 @@ type Central interface {
 	// ClusterStatsGet Get current health of cluster
 	// This method allows you to fetch cluster's health
 	ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuery) (model.ClusterHealthStats, error)
+	// ClusterStatsGetRaw Get cluster stats as raw bytes
+	// Returns the response body in the format selected by query.Format.
+	// The caller must close the returned body.
+	ClusterStatsGetRaw(ctx context.Context, query *ClusterStatsGetQuery) (io.ReadCloser, error)
 	// ConfigGet Get configuration and stats of Central
 	// This method allows you to fetch Central configuration and stats
 	ConfigGet(ctx context.Context, query *ConfigGetQuery) (model.CentralConfig, error)
+	// ConfigGetRaw Get config as raw bytes
+	// Returns the response body in the format selected by query.Format.
+	// The caller must close the returned body.
+	ConfigGetRaw(ctx context.Context, query *ConfigGetQuery) (io.ReadCloser, error)
 	// ConfigSave Update central configuration
 	// This method allows you to update central configuration. Calling this method will update config file but will not change the env.
 	ConfigSave(ctx context.Context, body model.CentralConfig) (model.CentralConfig, error)
@@ -224,10 +235,12 @@ type Central interface {
 	StreamersListIterator(ctx context.Context, query *StreamersListQuery) iter.Seq2[model.StreamerConfig, error]
 	// StreamingLbPlayback Playback load balance
 	// This method allows you to play a stream from the least loaded media server.
-	StreamingLbPlayback(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (any, error)
+	// The redirect is not followed, the chosen media server is returned in StreamingLbResult.Location.
+	StreamingLbPlayback(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (*StreamingLbResult, error)
 	// StreamingLbPublish Publish load balance
 	// This method allows to publish the stream to the least loaded media server.
-	StreamingLbPublish(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) error
+	// The redirect is not followed, the chosen media server is returned in StreamingLbResult.Location.
+	StreamingLbPublish(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (*StreamingLbResult, error)
 	// StreamsBatchUpdate Batch update streams
 	// This method allows you to update multiple streams in a single request. This operation does everything or nothing.  If at least one stream has an invalid configuration or can't be processed, the request will fail.  The request body should contain an array of stream objects with the fields to be updated. The update is applied using [JSON Merge Patch](https://tools.ietf.org/html/rfc7386) semantics.  If a stream does not exist, it will be created with the provided configuration.
 	StreamsBatchUpdate(ctx context.Context, body any) error
@@ -1399,7 +1412,16 @@ type BaseClientFactory func(cfg *config.Config, apiURL string, authKey authoriza
 
 // defaultBaseClientFactory creates a default base client instance.
 func defaultBaseClientFactory(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
-	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry,
+		append(baseclient.ConfigOptions(cfg), baseclient.WithProduct("central"))...)
+}
+
+// StreamingLbResult is the answer of a load balancer with the media server chosen for the stream.
+type StreamingLbResult struct {
+	// StatusCode of the load balancer response, usually a redirect status.
+	StatusCode int
+	// Location is the absolute URL of the chosen media server, empty if the balancer did not redirect.
+	Location string
 }
 
 // Client is the concrete implementation of the Central interface.
@@ -1440,7 +1462,15 @@ func NewWithBaseFactory(cfg *config.Config, baseFactory BaseClientFactory) (Cent
 		cfg.Originator = config.DefaultOriginator
 	}
 
-	base := baseFactory(cfg, apiURL, authKey)
+	httpClient, err := cfg.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+	// The caller's config is not changed, so it still builds a client from its settings when reused
+	withClient := *cfg
+	withClient.HTTPClient = httpClient
+
+	base := baseFactory(&withClient, apiURL, authKey)
 
 	return &Client{
 		base:   base,
@@ -1501,6 +1531,66 @@ func (c *Client) doDelete(ctx context.Context, path string) error {
 	return c.base.Request(ctx, request, nil)
 }
 
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, query interface{ ToQueryString() (string, error) }) (string, error) {
+	queryString, err := query.ToQueryString()
+	if err != nil {
+		return "", fmt.Errorf("failed to build query string: %w", err)
+	}
+	if queryString != "" {
+		path += "?" + queryString
+	}
+	return path, nil
+}
+
+// doStream performs a GET request with query parameters and returns the raw response body.
+// The caller must close the returned body.
+func (c *Client) doStream(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, accept string) (io.ReadCloser, error) {
+	if query != nil {
+		var err error
+		if path, err = withQuery(path, query); err != nil {
+			return nil, err
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	response, err := c.base.RequestStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// doRedirect performs a request without following redirects and returns the redirect target.
+func (c *Client) doRedirect(ctx context.Context, method string, path string) (*StreamingLbResult, error) {
+	request, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	response, err := c.base.RequestStream(baseclient.WithoutRedirects(ctx), request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	result := &StreamingLbResult{StatusCode: response.StatusCode}
+	location, err := response.Location()
+	switch {
+	case errors.Is(err, http.ErrNoLocation):
+	case err != nil:
+		return nil, fmt.Errorf("failed to parse redirect location: %w", err)
+	default:
+		result.Location = location.String()
+	}
+	return result, nil
+}
+
 // doList performs a GET request with query parameters and unmarshals the response into result.
 func (c *Client) doList(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, result any) error {
 	if query == nil {
@@ -1530,7 +1620,8 @@ func (c *Client) doList(ctx context.Context, path string, query interface{ ToQue
 // AgentDelete Delete Agent
 // Agent and corresponding camera(s) will be permanently deleted from the system.
 func (c *Client) AgentDelete(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/central/api/v3/agents/%s", id)
+	ctx = baseclient.WithOperation(ctx, "AgentDelete")
+	path := fmt.Sprintf("/central/api/v3/agents/%s", urlpath.Segment(id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1540,7 +1631,8 @@ func (c *Client) AgentDelete(ctx context.Context, id string) error {
 // AgentDisconnect Disconnect Agent
 // Disconnect the Agent by the Agent ID.
 func (c *Client) AgentDisconnect(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/central/api/v3/agents/%s/disconnect", id)
+	ctx = baseclient.WithOperation(ctx, "AgentDisconnect")
+	path := fmt.Sprintf("/central/api/v3/agents/%s/disconnect", urlpath.Segment(id))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1550,7 +1642,8 @@ func (c *Client) AgentDisconnect(ctx context.Context, id string) error {
 // AgentGet Get one Agent
 // The method allows you to fetch a single Agent.  The returned data are the same as for `agents_list` operation.
 func (c *Client) AgentGet(ctx context.Context, id string) (model.CentralAgentConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/agents/%s", id)
+	ctx = baseclient.WithOperation(ctx, "AgentGet")
+	path := fmt.Sprintf("/central/api/v3/agents/%s", urlpath.Segment(id))
 	result := &model.CentralAgentConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1561,7 +1654,8 @@ func (c *Client) AgentGet(ctx context.Context, id string) (model.CentralAgentCon
 // AgentLogsGet Get logs from Agent
 // The method allows you to fetch logs from online Agent.
 func (c *Client) AgentLogsGet(ctx context.Context, id string) (model.WatcherAgentLogs, error) {
-	path := fmt.Sprintf("/central/api/v3/agents/%s/logs", id)
+	ctx = baseclient.WithOperation(ctx, "AgentLogsGet")
+	path := fmt.Sprintf("/central/api/v3/agents/%s/logs", urlpath.Segment(id))
 	result := &model.WatcherAgentLogsImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1572,7 +1666,8 @@ func (c *Client) AgentLogsGet(ctx context.Context, id string) (model.WatcherAgen
 // AgentReboot Reboot Agent
 // Reboot the Agent by the Agent ID.
 func (c *Client) AgentReboot(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/central/api/v3/agents/%s/reboot", id)
+	ctx = baseclient.WithOperation(ctx, "AgentReboot")
+	path := fmt.Sprintf("/central/api/v3/agents/%s/reboot", urlpath.Segment(id))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1582,7 +1677,8 @@ func (c *Client) AgentReboot(ctx context.Context, id string) error {
 // AgentReset Reset Agent
 // Reset the Agent by the Agent ID.
 func (c *Client) AgentReset(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/central/api/v3/agents/%s/reset", id)
+	ctx = baseclient.WithOperation(ctx, "AgentReset")
+	path := fmt.Sprintf("/central/api/v3/agents/%s/reset", urlpath.Segment(id))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1592,7 +1688,8 @@ func (c *Client) AgentReset(ctx context.Context, id string) error {
 // AgentSave Save Agent
 // Save the Agent parameters by the Agent ID.
 func (c *Client) AgentSave(ctx context.Context, id string, body model.CentralAgentConfig) (model.CentralAgentConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/agents/%s", id)
+	ctx = baseclient.WithOperation(ctx, "AgentSave")
+	path := fmt.Sprintf("/central/api/v3/agents/%s", urlpath.Segment(id))
 	result := &model.CentralAgentConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1603,6 +1700,7 @@ func (c *Client) AgentSave(ctx context.Context, id string, body model.CentralAge
 // AgentsList List Agents
 // The list of configured and provisioned Agents, i.e. Agents that connected to your server at least once.
 func (c *Client) AgentsList(ctx context.Context, query *AgentsListQuery) (model.CentralAgentsList, error) {
+	ctx = baseclient.WithOperation(ctx, "AgentsList")
 	path := "/central/api/v3/agents"
 	result := &model.CentralAgentsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1619,7 +1717,8 @@ func (c *Client) AgentsListIterator(ctx context.Context, query *AgentsListQuery)
 // ApiTokenGet Get one API Token
 // Method allows to fetch single API token by name
 func (c *Client) ApiTokenGet(ctx context.Context, name string) (model.APIToken, error) {
-	path := fmt.Sprintf("/central/api/v3/api_tokens/%s", name)
+	ctx = baseclient.WithOperation(ctx, "ApiTokenGet")
+	path := fmt.Sprintf("/central/api/v3/api_tokens/%s", urlpath.Segment(name))
 	result := &model.APITokenImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1630,7 +1729,8 @@ func (c *Client) ApiTokenGet(ctx context.Context, name string) (model.APIToken,
 // ApiTokenSave Save API Token
 // Create a new API token or update an existing one by name.
 func (c *Client) ApiTokenSave(ctx context.Context, name string, body model.APIToken) (model.APIToken, error) {
-	path := fmt.Sprintf("/central/api/v3/api_tokens/%s", name)
+	ctx = baseclient.WithOperation(ctx, "ApiTokenSave")
+	path := fmt.Sprintf("/central/api/v3/api_tokens/%s", urlpath.Segment(name))
 	result := &model.APITokenImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1641,6 +1741,7 @@ func (c *Client) ApiTokenSave(ctx context.Context, name string, body model.APITo
 // ApiTokensList List API tokens
 // Method allows to fetch configured API tokens
 func (c *Client) ApiTokensList(ctx context.Context, query *ApiTokensListQuery) (model.APITokensList, error) {
+	ctx = baseclient.WithOperation(ctx, "ApiTokensList")
 	path := "/central/api/v3/api_tokens"
 	result := &model.APITokensListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1657,7 +1758,8 @@ func (c *Client) ApiTokensListIterator(ctx context.Context, query *ApiTokensList
 // AuthBackendDelete Delete the auth backend
 // Delete the authorization backend by its name.
 func (c *Client) AuthBackendDelete(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", name)
+	ctx = baseclient.WithOperation(ctx, "AuthBackendDelete")
+	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", urlpath.Segment(name))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1667,7 +1769,8 @@ func (c *Client) AuthBackendDelete(ctx context.Context, name string) error {
 // AuthBackendGet Get the auth backend
 // This method allows to get a single authorization backend.
 func (c *Client) AuthBackendGet(ctx context.Context, name string) (model.AuthBackendConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", name)
+	ctx = baseclient.WithOperation(ctx, "AuthBackendGet")
+	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", urlpath.Segment(name))
 	result := &model.AuthBackendConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1678,7 +1781,8 @@ func (c *Client) AuthBackendGet(ctx context.Context, name string) (model.AuthBac
 // AuthBackendSave Save the auth backend
 // Create or update an authorization backend.  If you pass only a partial configuration, only the passed fields will be updated.
 func (c *Client) AuthBackendSave(ctx context.Context, name string, body model.AuthBackendConfig) (model.AuthBackendConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", name)
+	ctx = baseclient.WithOperation(ctx, "AuthBackendSave")
+	path := fmt.Sprintf("/central/api/v3/auth_backends/%s", urlpath.Segment(name))
 	result := &model.AuthBackendConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1689,6 +1793,7 @@ func (c *Client) AuthBackendSave(ctx context.Context, name string, body model.Au
 // AuthBackendsList List auth backends
 // This method allows to get the list of all authorization backends configured in Flussonic.
 func (c *Client) AuthBackendsList(ctx context.Context, query *AuthBackendsListQuery) (model.AuthBackendsList, error) {
+	ctx = baseclient.WithOperation(ctx, "AuthBackendsList")
 	path := "/central/api/v3/auth_backends"
 	result := &model.AuthBackendsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1705,6 +1810,7 @@ func (c *Client) AuthBackendsListIterator(ctx context.Context, query *AuthBacken
 // AuthRequest Play session authorization
 // This method describes what Flussonic sends to an authorization backend for playing session and what it awaits as a response.
 func (c *Client) AuthRequest(ctx context.Context, query *AuthRequestQuery) (model.AuthResponseV1, error) {
+	ctx = baseclient.WithOperation(ctx, "AuthRequest")
 	path := "/central/api/v3/auth"
 	result := &model.AuthResponseV1Impl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1716,7 +1822,11 @@ func (c *Client) AuthRequest(ctx context.Context, query *AuthRequestQuery) (mode
 // BatchStreamLayoutPreview Dry run layouter for batch of changed stream configurations
 // This method allows you to see how the overall layout will change due to changes in the configurations of multiple streams. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) BatchStreamLayoutPreview(ctx context.Context, query *BatchStreamLayoutPreviewQuery, body any) (model.CentralStreamLayoutPrediction, error) {
-	path := "/central/api/v3/streams/preview_layout_change"
+	ctx = baseclient.WithOperation(ctx, "BatchStreamLayoutPreview")
+	path, err := withQuery("/central/api/v3/streams/preview_layout_change", query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1734,7 +1844,11 @@ func (c *Client) BatchStreamLayoutPreviewIterator(ctx context.Context, query *Ba
 // BatchStreamerLayoutPreview Dry run layouter for batch of changed streamer configurations
 // This method allows you to see how the overall layout will be changed due to changes in the configurations of multiple streamers. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) BatchStreamerLayoutPreview(ctx context.Context, query *BatchStreamerLayoutPreviewQuery, body any) (model.CentralStreamerLayoutPrediction, error) {
-	path := "/central/api/v3/streamers/preview_layout_change"
+	ctx = baseclient.WithOperation(ctx, "BatchStreamerLayoutPreview")
+	path, err := withQuery("/central/api/v3/streamers/preview_layout_change", query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamerLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1752,6 +1866,7 @@ func (c *Client) BatchStreamerLayoutPreviewIterator(ctx context.Context, query *
 // BatchUpdateAgentsLayouts Batch update layouts of agents
 // Batch update layouts of agents. This operation does everything or nothing. Only agents without streams will be processed, other ones would be ignored. If at least one agent has an invalid configuration or can't be processed, the request will fail.
 func (c *Client) BatchUpdateAgentsLayouts(ctx context.Context, body model.CentralAgentLayoutList) error {
+	ctx = baseclient.WithOperation(ctx, "BatchUpdateAgentsLayouts")
 	path := "/central/api/v3/agents/layouts"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1762,6 +1877,7 @@ func (c *Client) BatchUpdateAgentsLayouts(ctx context.Context, body model.Centra
 // BatchUpdateStreamsLayouts Batch update layouts of streams
 // Batch update layouts of streams. This operation does everything or nothing.  If at least one stream has an invalid configuration or can't be processed, the request will fail.
 func (c *Client) BatchUpdateStreamsLayouts(ctx context.Context, body model.CentralStreamLayoutList) error {
+	ctx = baseclient.WithOperation(ctx, "BatchUpdateStreamsLayouts")
 	path := "/central/api/v3/streams/layouts"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1772,6 +1888,7 @@ func (c *Client) BatchUpdateStreamsLayouts(ctx context.Context, body model.Centr
 // CentralEventsList Get events of Central
 // This method allows to get the batch of events. Currently this method is not implemented yet. However, its schema can be used for understanding Central logging.
 func (c *Client) CentralEventsList(ctx context.Context, query *CentralEventsListQuery) (model.CentralEventsList, error) {
+	ctx = baseclient.WithOperation(ctx, "CentralEventsList")
 	path := "/central/api/v3/events"
 	result := &model.CentralEventsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1788,6 +1905,7 @@ func (c *Client) CentralEventsListIterator(ctx context.Context, query *CentralEv
 // ClusterStatsGet Get current health of cluster
 // This method allows you to fetch cluster's health
 func (c *Client) ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuery) (model.ClusterHealthStats, error) {
+	ctx = baseclient.WithOperation(ctx, "ClusterStatsGet")
 	path := "/central/api/v3/cluster/stats"
 	result := &model.ClusterHealthStatsImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1796,9 +1914,19 @@ func (c *Client) ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuer
 	return result, nil
 }
 
+// ClusterStatsGetRaw Get cluster stats as raw bytes
+// Returns the response body in the format selected by query.Format.
+// The caller must close the returned body.
+func (c *Client) ClusterStatsGetRaw(ctx context.Context, query *ClusterStatsGetQuery) (io.ReadCloser, error) {
+	ctx = baseclient.WithOperation(ctx, "ClusterStatsGetRaw")
+	path := "/central/api/v3/cluster/stats"
+	return c.doStream(ctx, path, query, "")
+}
+
 // ConfigGet Get configuration and stats of Central
 // This method allows you to fetch Central configuration and stats
 func (c *Client) ConfigGet(ctx context.Context, query *ConfigGetQuery) (model.CentralConfig, error) {
+	ctx = baseclient.WithOperation(ctx, "ConfigGet")
 	path := "/central/api/v3/config"
 	result := &model.CentralConfigImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1807,9 +1935,19 @@ func (c *Client) ConfigGet(ctx context.Context, query *ConfigGetQuery) (model.Ce
 	return result, nil
 }
 
+// ConfigGetRaw Get config as raw bytes
+// Returns the response body in the format selected by query.Format.
+// The caller must close the returned body.
+func (c *Client) ConfigGetRaw(ctx context.Context, query *ConfigGetQuery) (io.ReadCloser, error) {
+	ctx = baseclient.WithOperation(ctx, "ConfigGetRaw")
+	path := "/central/api/v3/config"
+	return c.doStream(ctx, path, query, "")
+}
+
 // ConfigSave Update central configuration
 // This method allows you to update central configuration. Calling this method will update config file but will not change the env.
 func (c *Client) ConfigSave(ctx context.Context, body model.CentralConfig) (model.CentralConfig, error) {
+	ctx = baseclient.WithOperation(ctx, "ConfigSave")
 	path := "/central/api/v3/config"
 	result := &model.CentralConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
@@ -1821,6 +1959,7 @@ func (c *Client) ConfigSave(ctx context.Context, body model.CentralConfig) (mode
 // DvrsList List of DVR configurations
 // This method allows to get the whole list of global DVR congifurations.
 func (c *Client) DvrsList(ctx context.Context, query *DvrsListQuery) (model.CentralDvrsList, error) {
+	ctx = baseclient.WithOperation(ctx, "DvrsList")
 	path := "/central/api/v3/dvrs"
 	result := &model.CentralDvrsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1837,7 +1976,8 @@ func (c *Client) DvrsListIterator(ctx context.Context, query *DvrsListQuery) ite
 // EpisodeDelete Delete the Episode
 // This method allows you to delete an episode
 func (c *Client) EpisodeDelete(ctx context.Context, episode_id string) error {
-	path := fmt.Sprintf("/central/api/v3/episodes/%s", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeDelete")
+	path := fmt.Sprintf("/central/api/v3/episodes/%s", urlpath.Segment(episode_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1847,7 +1987,8 @@ func (c *Client) EpisodeDelete(ctx context.Context, episode_id string) error {
 // EpisodeGet Get the episode
 // This method allows you to fetch the episode by its identifier
 func (c *Client) EpisodeGet(ctx context.Context, episode_id string, query *EpisodeGetQuery) (model.Episode, error) {
-	path := fmt.Sprintf("/central/api/v3/episodes/%s", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeGet")
+	path := fmt.Sprintf("/central/api/v3/episodes/%s", urlpath.Segment(episode_id))
 	result := &model.EpisodeImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1858,7 +1999,8 @@ func (c *Client) EpisodeGet(ctx context.Context, episode_id string, query *Episo
 // EpisodeSave Save the Episode
 // This method allows you to create a new episode or update attributes of the existing one
 func (c *Client) EpisodeSave(ctx context.Context, episode_id string, body model.Episode) (model.Episode, error) {
-	path := fmt.Sprintf("/central/api/v3/episodes/%s", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeSave")
+	path := fmt.Sprintf("/central/api/v3/episodes/%s", urlpath.Segment(episode_id))
 	result := &model.EpisodeImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1869,6 +2011,7 @@ func (c *Client) EpisodeSave(ctx context.Context, episode_id string, body model.
 // EpisodesList List episodes
 // This method allows you to fetch registered episodes
 func (c *Client) EpisodesList(ctx context.Context, query *EpisodesListQuery) (model.EpisodesList, error) {
+	ctx = baseclient.WithOperation(ctx, "EpisodesList")
 	path := "/central/api/v3/episodes"
 	result := &model.EpisodesListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1885,7 +2028,8 @@ func (c *Client) EpisodesListIterator(ctx context.Context, query *EpisodesListQu
 // ExternalEpisodesList External episodes
 // This method will return episodes for selected streams that should be  left on disk during DVR cleaning process.
 func (c *Client) ExternalEpisodesList(ctx context.Context, hostname string, query *ExternalEpisodesListQuery) (model.ExternalEpisodesList, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/episodes", hostname)
+	ctx = baseclient.WithOperation(ctx, "ExternalEpisodesList")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s/episodes", urlpath.Segment(hostname))
 	result := &model.ExternalEpisodesListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1903,6 +2047,7 @@ func (c *Client) ExternalEpisodesListIterator(ctx context.Context, hostname stri
 // LivenessProbe Liveness probe
 // K8s liveness probe.
 func (c *Client) LivenessProbe(ctx context.Context) (model.ServerStatsWhoami, error) {
+	ctx = baseclient.WithOperation(ctx, "LivenessProbe")
 	path := "/central/api/v3/monitoring/liveness"
 	result := &model.ServerStatsWhoamiImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1914,7 +2059,8 @@ func (c *Client) LivenessProbe(ctx context.Context) (model.ServerStatsWhoami, er
 // LoadBalancerDelete Delete the Load Balancer
 // This method allows you to delete a load balancer
 func (c *Client) LoadBalancerDelete(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", name)
+	ctx = baseclient.WithOperation(ctx, "LoadBalancerDelete")
+	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", urlpath.Segment(name))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1924,7 +2070,8 @@ func (c *Client) LoadBalancerDelete(ctx context.Context, name string) error {
 // LoadBalancerGet Get the load balancer
 // This method allows you to fetch the load balancer by its name
 func (c *Client) LoadBalancerGet(ctx context.Context, name string) (model.BalancerConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", name)
+	ctx = baseclient.WithOperation(ctx, "LoadBalancerGet")
+	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", urlpath.Segment(name))
 	result := &model.BalancerConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1935,7 +2082,8 @@ func (c *Client) LoadBalancerGet(ctx context.Context, name string) (model.Balanc
 // LoadBalancerSave Save the Load Balancer
 // This method allows you to create a new load balancer or update attributes of the existing one
 func (c *Client) LoadBalancerSave(ctx context.Context, name string, body model.BalancerConfig) (model.BalancerConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", name)
+	ctx = baseclient.WithOperation(ctx, "LoadBalancerSave")
+	path := fmt.Sprintf("/central/api/v3/load-balancers/%s", urlpath.Segment(name))
 	result := &model.BalancerConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1946,6 +2094,7 @@ func (c *Client) LoadBalancerSave(ctx context.Context, name string, body model.B
 // LoadBalancersList List load balancers
 // This method allows you to fetch registered load balancers.  Load balancers are used in [streaming api](https://flussonic.com/doc/api/central/#tag/streaming).
 func (c *Client) LoadBalancersList(ctx context.Context, query *LoadBalancersListQuery) (model.BalancersList, error) {
+	ctx = baseclient.WithOperation(ctx, "LoadBalancersList")
 	path := "/central/api/v3/load-balancers"
 	result := &model.BalancersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1962,7 +2111,8 @@ func (c *Client) LoadBalancersListIterator(ctx context.Context, query *LoadBalan
 // PersonDelete Delete the Person
 // This method allows you to delete the person by its identifier
 func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
-	path := fmt.Sprintf("/central/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonDelete")
+	path := fmt.Sprintf("/central/api/v3/persons/%s", urlpath.Segment(person_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1972,7 +2122,8 @@ func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
 // PersonGet Get the Person
 // This method allows you to fetch the person by its identifier
 func (c *Client) PersonGet(ctx context.Context, person_id string) (model.VisionPerson, error) {
-	path := fmt.Sprintf("/central/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonGet")
+	path := fmt.Sprintf("/central/api/v3/persons/%s", urlpath.Segment(person_id))
 	result := &model.VisionPersonImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1983,7 +2134,8 @@ func (c *Client) PersonGet(ctx context.Context, person_id string) (model.VisionP
 // PersonSave Save the Person
 // This method allows you to create a new person or update attributes of the existing one
 func (c *Client) PersonSave(ctx context.Context, person_id string, body model.VisionPerson) (model.VisionPerson, error) {
-	path := fmt.Sprintf("/central/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonSave")
+	path := fmt.Sprintf("/central/api/v3/persons/%s", urlpath.Segment(person_id))
 	result := &model.VisionPersonImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1994,6 +2146,7 @@ func (c *Client) PersonSave(ctx context.Context, person_id string, body model.Vi
 // PersonsList List Persons
 // The list of registered videoanalytics persons
 func (c *Client) PersonsList(ctx context.Context, query *PersonsListQuery) (model.VisionPersonsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PersonsList")
 	path := "/central/api/v3/persons"
 	result := &model.VisionPersonsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -2010,7 +2163,11 @@ func (c *Client) PersonsListIterator(ctx context.Context, query *PersonsListQuer
 // PreviewLayoutChangeForStreamConfig Dry run layouter for changed stream configuration
 // This method allows you to see how the overall layout will be changed due to changes into stream configuration. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) PreviewLayoutChangeForStreamConfig(ctx context.Context, name string, query *PreviewLayoutChangeForStreamConfigQuery, body model.CentralStreamConfig) (model.CentralStreamLayoutPrediction, error) {
-	path := fmt.Sprintf("/central/api/v3/streams/%s/preview_layout_change", name)
+	ctx = baseclient.WithOperation(ctx, "PreviewLayoutChangeForStreamConfig")
+	path, err := withQuery(fmt.Sprintf("/central/api/v3/streams/%s/preview_layout_change", urlpath.Segment(name)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2028,7 +2185,11 @@ func (c *Client) PreviewLayoutChangeForStreamConfigIterator(ctx context.Context,
 // PreviewLayoutChangeForStreamerConfig Dry run layouter for changed streamer configuration
 // This method allows you to see how the overall layout will be changed due to changes into streamer configuration. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) PreviewLayoutChangeForStreamerConfig(ctx context.Context, hostname string, query *PreviewLayoutChangeForStreamerConfigQuery, body model.StreamerConfig) (model.CentralStreamerLayoutPrediction, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/preview_layout_change", hostname)
+	ctx = baseclient.WithOperation(ctx, "PreviewLayoutChangeForStreamerConfig")
+	path, err := withQuery(fmt.Sprintf("/central/api/v3/streamers/%s/preview_layout_change", urlpath.Segment(hostname)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamerLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2046,6 +2207,7 @@ func (c *Client) PreviewLayoutChangeForStreamerConfigIterator(ctx context.Contex
 // ReadinessProbe Readiness probe
 // K8s readiness probe.
 func (c *Client) ReadinessProbe(ctx context.Context) (model.ServerStatsWhoami, error) {
+	ctx = baseclient.WithOperation(ctx, "ReadinessProbe")
 	path := "/central/api/v3/monitoring/readiness"
 	result := &model.ServerStatsWhoamiImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -2057,9 +2219,10 @@ func (c *Client) ReadinessProbe(ctx context.Context) (model.ServerStatsWhoami, e
 // SrtPortResolve UDP port to stream name
 // This API method will be called to find stream name by the port which received srt connection.  SRT protocol has two modes: when no stream name is passed in protocol, or when it is passed, but only if passwords match on both sides.  So you cannot rely on a stream name, because it is either not passed, either is password protected.  When you build a cloud, you cannot offer the same password to all clients, because it is just the same like without password.  This method allows some dynamic configuration when you wait for a client on a pool of servers on a fixed UDP port. When connection comes (publish/ingress of play/egress), your configuration backend will be called for resolving this UDP port to a stream configuration. Streamer will find out what is the name of the stream, its password and all other settings.
 func (c *Client) SrtPortResolve(ctx context.Context, hostname string, port string, query *SrtPortResolveQuery) (any, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/srt_port_resolve/%s", hostname, port)
+	ctx = baseclient.WithOperation(ctx, "SrtPortResolve")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s/srt_port_resolve/%s", urlpath.Segment(hostname), urlpath.Segment(port))
 	var result any
-	if err := c.doList(ctx, path, query, nil); err != nil {
+	if err := c.doList(ctx, path, query, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -2068,7 +2231,8 @@ func (c *Client) SrtPortResolve(ctx context.Context, hostname string, port strin
 // StreamDelete Delete stream
 // The stream will be permanently deleted from the project.
 func (c *Client) StreamDelete(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/central/api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamDelete")
+	path := fmt.Sprintf("/central/api/v3/streams/%s", urlpath.Segment(name))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2078,7 +2242,8 @@ func (c *Client) StreamDelete(ctx context.Context, name string) error {
 // StreamGet Get one stream
 // This method allows you to fetch a single stream.  The data returned in this method are the same as for `streams_list` operation.
 func (c *Client) StreamGet(ctx context.Context, name string) (model.CentralStreamConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamGet")
+	path := fmt.Sprintf("/central/api/v3/streams/%s", urlpath.Segment(name))
 	result := &model.CentralStreamConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2089,7 +2254,8 @@ func (c *Client) StreamGet(ctx context.Context, name string) (model.CentralStrea
 // StreamLayoutsGet Get layouts of stream
 // This method allows you to fetch stream layouts list
 func (c *Client) StreamLayoutsGet(ctx context.Context, name string, query *StreamLayoutsGetQuery) (model.CentralStreamLayouts, error) {
-	path := fmt.Sprintf("/central/api/v3/streams/%s/layouts", name)
+	ctx = baseclient.WithOperation(ctx, "StreamLayoutsGet")
+	path := fmt.Sprintf("/central/api/v3/streams/%s/layouts", urlpath.Segment(name))
 	result := &model.CentralStreamLayoutsImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2107,7 +2273,8 @@ func (c *Client) StreamLayoutsGetIterator(ctx context.Context, name string, quer
 // StreamSave Save stream
 // Create or update a stream by its name. If the stream doesn't exists in the disk config, it will be created.  If you pass only a partial stream configuration, just the passed field(s) will be updated, not the whole stream.  To create a new stream the property `name` is required.  Pass the `"$reset": true` option to replace the stream configuration with the provided one.
 func (c *Client) StreamSave(ctx context.Context, name string, body model.CentralStreamConfig) (model.CentralStreamConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamSave")
+	path := fmt.Sprintf("/central/api/v3/streams/%s", urlpath.Segment(name))
 	result := &model.CentralStreamConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2118,7 +2285,8 @@ func (c *Client) StreamSave(ctx context.Context, name string, body model.Central
 // StreamerDelete Delete the streamer
 // Delete the streamer by its hostname.
 func (c *Client) StreamerDelete(ctx context.Context, hostname string) error {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerDelete")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s", urlpath.Segment(hostname))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2128,7 +2296,8 @@ func (c *Client) StreamerDelete(ctx context.Context, hostname string) error {
 // StreamerDynamicStreamsList Get dynamic streams for streamer
 // This API method will be called to fetch list of streams configurations for newly requested by user streams.  If you do not send a configuration of any stream named in `name` query parameter, it will be considered as an unknown and terminated immediately
 func (c *Client) StreamerDynamicStreamsList(ctx context.Context, hostname string, query *StreamerDynamicStreamsListQuery) (model.StreamsList, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/dynamic-streams", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerDynamicStreamsList")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s/dynamic-streams", urlpath.Segment(hostname))
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2146,7 +2315,8 @@ func (c *Client) StreamerDynamicStreamsListIterator(ctx context.Context, hostnam
 // StreamerGet Get the streamer
 // This method allows you to fetch the main parameters and current metrics of a single streamer
 func (c *Client) StreamerGet(ctx context.Context, hostname string) (model.StreamerConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerGet")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s", urlpath.Segment(hostname))
 	result := &model.StreamerConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2157,7 +2327,11 @@ func (c *Client) StreamerGet(ctx context.Context, hostname string) (model.Stream
 // StreamerSave Save the streamer
 // This method will either create a new streamer (if there is no streamer with the hostname specified in the request), or update the existing streamer having the specified hostname.
 func (c *Client) StreamerSave(ctx context.Context, hostname string, query *StreamerSaveQuery, body model.StreamerConfig) (model.StreamerConfig, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerSave")
+	path, err := withQuery(fmt.Sprintf("/central/api/v3/streamers/%s", urlpath.Segment(hostname)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.StreamerConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2168,7 +2342,8 @@ func (c *Client) StreamerSave(ctx context.Context, hostname string, query *Strea
 // StreamerStreamsList Get streams for streamer
 // Return list of configured streams for the streamer
 func (c *Client) StreamerStreamsList(ctx context.Context, hostname string, query *StreamerStreamsListQuery) (model.StreamsList, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/streams", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerStreamsList")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s/streams", urlpath.Segment(hostname))
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2186,7 +2361,8 @@ func (c *Client) StreamerStreamsListIterator(ctx context.Context, hostname strin
 // StreamerUpdateStreamsList Get update streams for streamer
 // This API method will be called to fetch list of streams configurations.  Streamer will send here list of streams that were not enumerated in `streams_list` response.  Use this to shutdown unknown streams or dynamically configure streams.  If you do not send a configuration of any stream named in `name` query parameter, it will be considered as an unknown and terminated immediately
 func (c *Client) StreamerUpdateStreamsList(ctx context.Context, hostname string, query *StreamerUpdateStreamsListQuery) (model.StreamsList, error) {
-	path := fmt.Sprintf("/central/api/v3/streamers/%s/update-streams", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerUpdateStreamsList")
+	path := fmt.Sprintf("/central/api/v3/streamers/%s/update-streams", urlpath.Segment(hostname))
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2204,6 +2380,7 @@ func (c *Client) StreamerUpdateStreamsListIterator(ctx context.Context, hostname
 // StreamersBatchUpdate Batch update streamers
 // This method allows you to update multiple streamers in a single request. This operation does everything or nothing.  If at least one streamer has an invalid configuration or can't be processed, the request will fail.  The request body should contain an array of streamer objects with the fields to be updated. The update is applied using [JSON Merge Patch](https://tools.ietf.org/html/rfc7386) semantics.  If a streamer does not exist, it will be created with the provided configuration.
 func (c *Client) StreamersBatchUpdate(ctx context.Context, body any) error {
+	ctx = baseclient.WithOperation(ctx, "StreamersBatchUpdate")
 	path := "/central/api/v3/streamers/batch"
 	if err := c.doPut(ctx, path, body, nil); err != nil {
 		return err
@@ -2214,6 +2391,7 @@ func (c *Client) StreamersBatchUpdate(ctx context.Context, body any) error {
 // StreamersList List streamers
 // Returns the list of all streamers with main parameters and current metric values.
 func (c *Client) StreamersList(ctx context.Context, query *StreamersListQuery) (model.StreamersList, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamersList")
 	path := "/central/api/v3/streamers"
 	result := &model.StreamersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -2229,28 +2407,26 @@ func (c *Client) StreamersListIterator(ctx context.Context, query *StreamersList
 
 // StreamingLbPlayback Playback load balance
 // This method allows you to play a stream from the least loaded media server.
-func (c *Client) StreamingLbPlayback(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (any, error) {
-	path := fmt.Sprintf("/central/api/v3/lb/%s/%s/%s", loadbalancer_name, escaped_stream_name, multi_segment_suffix)
-	var result any
-	if err := c.doGet(ctx, path, result); err != nil {
-		return nil, err
-	}
-	return result, nil
+// The redirect is not followed, the chosen media server is returned in StreamingLbResult.Location.
+func (c *Client) StreamingLbPlayback(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (*StreamingLbResult, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamingLbPlayback")
+	path := fmt.Sprintf("/central/api/v3/lb/%s/%s/%s", urlpath.Segment(loadbalancer_name), urlpath.Segment(escaped_stream_name), urlpath.Subpath(multi_segment_suffix))
+	return c.doRedirect(ctx, http.MethodGet, path)
 }
 
 // StreamingLbPublish Publish load balance
 // This method allows to publish the stream to the least loaded media server.
-func (c *Client) StreamingLbPublish(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) error {
-	path := fmt.Sprintf("/central/api/v3/lb/%s/%s/%s", loadbalancer_name, escaped_stream_name, multi_segment_suffix)
-	if err := c.doPost(ctx, path, nil, nil); err != nil {
-		return err
-	}
-	return nil
+// The redirect is not followed, the chosen media server is returned in StreamingLbResult.Location.
+func (c *Client) StreamingLbPublish(ctx context.Context, loadbalancer_name string, escaped_stream_name string, multi_segment_suffix string) (*StreamingLbResult, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamingLbPublish")
+	path := fmt.Sprintf("/central/api/v3/lb/%s/%s/%s", urlpath.Segment(loadbalancer_name), urlpath.Segment(escaped_stream_name), urlpath.Subpath(multi_segment_suffix))
+	return c.doRedirect(ctx, http.MethodPost, path)
 }
 
 // StreamsBatchUpdate Batch update streams
 // This method allows you to update multiple streams in a single request. This operation does everything or nothing.  If at least one stream has an invalid configuration or can't be processed, the request will fail.  The request body should contain an array of stream objects with the fields to be updated. The update is applied using [JSON Merge Patch](https://tools.ietf.org/html/rfc7386) semantics.  If a stream does not exist, it will be created with the provided configuration.
 func (c *Client) StreamsBatchUpdate(ctx context.Context, body any) error {
+	ctx = baseclient.WithOperation(ctx, "StreamsBatchUpdate")
 	path := "/central/api/v3/streams/batch"
 	if err := c.doPut(ctx, path, body, nil); err != nil {
 		return err
@@ -2261,6 +2437,7 @@ func (c *Client) StreamsBatchUpdate(ctx context.Context, body any) error {
 // StreamsList List streams
 // This API method is one of the most important in whole API, because it gives the list of all streams, including:  * configured (maybe not running at the moment) * running (including those that are created via template and do not have own disk configuration) * remote (available from other servers) * only recorded  You can pass any stream configuration options to the `q` query string for filtering,  not only those specified in the `select` parameters.  This method, as well as `stream_get`, will return the **effective stream configuration** with all templates, defaults included into the response. It is not exactly what you can see in your disk config. If you have configured this stream in disk file, then the original disk configuration can be found in the `config_on_disk` field.  Thus, what you get from this API call may differ from what you write to `stream_save` which is another important method for managing streams.
 func (c *Client) StreamsList(ctx context.Context, query *StreamsListQuery) (model.CentralStreamsList, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamsList")
 	path := "/central/api/v3/streams"
 	result := &model.CentralStreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/flussonic/go-flussonic/authorization"
 	central "github.com/flussonic/go-flussonic/central"
@@ -2183,7 +2188,7 @@ func TestClient_StreamingLbPublish(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	err := client.StreamingLbPublish(ctx, "test-loadbalancer_name", "test-escaped_stream_name", "test-multi_segment_suffix")
+	_, err := client.StreamingLbPublish(ctx, "test-loadbalancer_name", "test-escaped_stream_name", "test-multi_segment_suffix")
 	if err != nil {
 		t.Fatalf("StreamingLbPublish failed: %v", err)
 	}
@@ -2259,6 +2264,360 @@ func TestClient_StreamsList(t *testing.T) {
 	verifyMarshalUnmarshal(t, result, expectedJSON)
 }
 
+func TestClient_StreamerSaveSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &central.StreamerSaveQuery{ChecksEnable: true}
+	if _, err := client.StreamerSave(ctx, "test-hostname", query, &model.StreamerConfigImpl{}); err != nil {
+		t.Fatalf("StreamerSave failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("checks_enable"); got != "true" {
+		t.Errorf("Expected query checks_enable=true, got %q", got)
+	}
+}
+
+func TestClient_PreviewLayoutChangeSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	streamQuery := &central.PreviewLayoutChangeForStreamConfigQuery{Limit: 5, Cursor: "next-page"}
+	if _, err := client.PreviewLayoutChangeForStreamConfig(ctx, "test-name", streamQuery, &model.CentralStreamConfigImpl{}); err != nil {
+		t.Fatalf("PreviewLayoutChangeForStreamConfig failed: %v", err)
+	}
+	if got := rt.request.URL.RawQuery; got != "cursor=next-page&limit=5" {
+		t.Errorf("Unexpected query %q", got)
+	}
+
+	batchQuery := &central.BatchStreamLayoutPreviewQuery{Limit: 7}
+	if _, err := client.BatchStreamLayoutPreview(ctx, batchQuery, map[string]any{}); err != nil {
+		t.Fatalf("BatchStreamLayoutPreview failed: %v", err)
+	}
+	if got := rt.request.URL.RawQuery; got != "limit=7" {
+		t.Errorf("Unexpected query %q", got)
+	}
+}
+
+func TestClient_ConfigGetRaw(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: "central config"}
+	client := createTestClient(t, rt)
+
+	body, err := client.ConfigGetRaw(ctx, &central.ConfigGetQuery{Format: "text"})
+	if err != nil {
+		t.Fatalf("ConfigGetRaw failed: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(data) != "central config" {
+		t.Errorf("Expected raw body, got %q", data)
+	}
+	if got := rt.request.URL.Query().Get("format"); got != "text" {
+		t.Errorf("Expected query format=text, got %q", got)
+	}
+}
+
+func TestClient_StreamGetEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for name, expected := range map[string]string{
+		"mylive/bunny":      "/central/api/v3/streams/mylive%2Fbunny",
+		"my stream":         "/central/api/v3/streams/my%20stream",
+		"камера":            "/central/api/v3/streams/%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0",
+		"../../config":      "/central/api/v3/streams/..%2F..%2Fconfig",
+		"..":                "/central/api/v3/streams/%2E%2E",
+		"name?format=csv#x": "/central/api/v3/streams/name%3Fformat=csv%23x",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.StreamGet(ctx, name); err != nil {
+			t.Fatalf("StreamGet(%q) failed: %v", name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("StreamGet(%q): expected path %s, got %s", name, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("StreamGet(%q): unexpected query %q", name, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_QueryMethodsEscapeName(t *testing.T) {
+	ctx := context.Background()
+	name := "../live/cam?x=1#y"
+	for _, tt := range []struct {
+		method string
+		path   string
+		query  string
+		call   func(client central.Central) error
+	}{
+		{
+			"PreviewLayoutChangeForStreamConfig",
+			"/central/api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y/preview_layout_change",
+			"limit=10",
+			func(client central.Central) error {
+				_, err := client.PreviewLayoutChangeForStreamConfig(ctx, name, &central.PreviewLayoutChangeForStreamConfigQuery{Limit: 10}, &model.CentralStreamConfigImpl{})
+				return err
+			},
+		},
+		{
+			"PreviewLayoutChangeForStreamerConfig",
+			"/central/api/v3/streamers/..%2Flive%2Fcam%3Fx=1%23y/preview_layout_change",
+			"limit=10",
+			func(client central.Central) error {
+				_, err := client.PreviewLayoutChangeForStreamerConfig(ctx, name, &central.PreviewLayoutChangeForStreamerConfigQuery{Limit: 10}, &model.StreamerConfigImpl{})
+				return err
+			},
+		},
+		{
+			"StreamerSave",
+			"/central/api/v3/streamers/..%2Flive%2Fcam%3Fx=1%23y",
+			"checks_enable=true",
+			func(client central.Central) error {
+				_, err := client.StreamerSave(ctx, name, &central.StreamerSaveQuery{ChecksEnable: true}, &model.StreamerConfigImpl{})
+				return err
+			},
+		},
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if err := tt.call(client); err != nil {
+			t.Fatalf("%s(%q) failed: %v", tt.method, name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != tt.path {
+			t.Errorf("%s(%q): expected path %s, got %s", tt.method, name, tt.path, got)
+		}
+		if rt.request.URL.RawQuery != tt.query {
+			t.Errorf("%s(%q): expected query %q, got %q", tt.method, name, tt.query, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_StreamingLbPlaybackEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for name, expected := range map[string]string{
+		"mylive/bunny": "/central/api/v3/lb/balancer/mylive%2Fbunny/index.m3u8",
+		"камера":       "/central/api/v3/lb/balancer/%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0/index.m3u8",
+		"..":           "/central/api/v3/lb/balancer/%2E%2E/index.m3u8",
+		"name?x#y":     "/central/api/v3/lb/balancer/name%3Fx%23y/index.m3u8",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.StreamingLbPlayback(ctx, "balancer", name, "index.m3u8"); err != nil {
+			t.Fatalf("StreamingLbPlayback(%q) failed: %v", name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("StreamingLbPlayback(%q): expected path %s, got %s", name, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("StreamingLbPlayback(%q): unexpected query %q", name, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_StreamingLbPlaybackReturnsLocation(t *testing.T) {
+	ctx := context.Background()
+	rt := &redirectRoundTripper{location: "http://streamer1.example.com/bunny/index.m3u8?token=abc"}
+	client := createTestClient(t, rt)
+
+	result, err := client.StreamingLbPlayback(ctx, "balancer", "bunny", "index.m3u8")
+	if err != nil {
+		t.Fatalf("StreamingLbPlayback failed: %v", err)
+	}
+	if rt.calls != 1 {
+		t.Errorf("Expected redirect not to be followed, got %d requests", rt.calls)
+	}
+	if result.StatusCode != http.StatusFound {
+		t.Errorf("Expected status %d, got %d", http.StatusFound, result.StatusCode)
+	}
+	if result.Location != rt.location {
+		t.Errorf("Expected location %s, got %s", rt.location, result.Location)
+	}
+}
+
+func TestClient_StreamingLbPublishResolvesLocation(t *testing.T) {
+	ctx := context.Background()
+	rt := &redirectRoundTripper{location: "/bunny/publish"}
+	client := createTestClient(t, rt)
+
+	result, err := client.StreamingLbPublish(ctx, "balancer", "bunny", "publish")
+	if err != nil {
+		t.Fatalf("StreamingLbPublish failed: %v", err)
+	}
+	if rt.method != http.MethodPost {
+		t.Errorf("Expected method POST, got %s", rt.method)
+	}
+	if expected := "http://localhost:80/bunny/publish"; result.Location != expected {
+		t.Errorf("Expected location %s, got %s", expected, result.Location)
+	}
+}
+
+func TestClient_MetricsLabels(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	client, err := central.New(&config.Config{
+		Hostname:      "localhost",
+		HTTPClient:    &http.Client{Transport: &capturingRoundTripper{responseJSON: `{}`}},
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.StreamGet(ctx, "test-name"); err != nil {
+		t.Fatalf("StreamGet failed: %v", err)
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &data); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "flussonic.client.requests" {
+				continue
+			}
+			point := m.Data.(metricdata.Sum[int64]).DataPoints[0]
+			product, _ := point.Attributes.Value("product")
+			operation, _ := point.Attributes.Value("operation")
+			if product.AsString() != "central" || operation.AsString() != "StreamGet" {
+				t.Errorf("Expected central/StreamGet labels, got %s/%s", product.AsString(), operation.AsString())
+			}
+			return
+		}
+	}
+	t.Error("Expected flussonic.client.requests metric")
+}
+
+// redirectRoundTripper answers every request with a redirect to location
+type redirectRoundTripper struct {
+	location string
+	method   string
+	calls    int
+}
+
+func (rt *redirectRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	rt.method = req.Method
+	resp := &http.Response{
+		StatusCode: http.StatusFound,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	resp.Header.Set("Location", rt.location)
+	return resp, nil
+}
+
+func TestNew_TransportConfig(t *testing.T) {
+	_, err := central.New(&config.Config{
+		Hostname: "localhost",
+		TLS:      &config.TLS{CAFile: "/nonexistent/ca.pem"},
+	})
+	if err == nil {
+		t.Fatal("Expected error for missing CA bundle")
+	}
+
+	cfg := &config.Config{Hostname: "localhost", Timeout: time.Minute}
+	var httpClient *http.Client
+	baseFactory := func(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
+		httpClient = cfg.HTTPClient
+		return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	}
+	if _, err := central.NewWithBaseFactory(cfg, baseFactory); err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if httpClient == nil || httpClient.Timeout != time.Minute {
+		t.Errorf("Expected HTTP client with timeout built from config, got %+v", httpClient)
+	}
+	if cfg.HTTPClient != nil {
+		t.Errorf("Expected config of the caller to be unchanged, got HTTP client %+v", cfg.HTTPClient)
+	}
+
+	// A reused config builds the client from its changed settings
+	cfg.TLS = &config.TLS{CAFile: "/nonexistent/ca.pem"}
+	if _, err := central.New(cfg); err == nil {
+		t.Fatal("Expected error for missing CA bundle of reused config")
+	}
+}
+
+func TestClient_StreamSavePatch(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"name":"cam1","title":"Entrance"}`}
+	client := createTestClient(t, rt)
+
+	body := central.NewStreamPatch().Set(func(s model.CentralStreamConfig) {
+		s.SetTitle("Entrance")
+	}).Null("/comment").Model()
+	if title := body.Title(); title == nil || *title != "Entrance" {
+		t.Errorf("Expected patch model getters to return set values, got %v", title)
+	}
+	if _, err := client.StreamSave(ctx, "cam1", body); err != nil {
+		t.Fatalf("StreamSave failed: %v", err)
+	}
+	if string(rt.body) != `{"comment":null,"title":"Entrance"}` {
+		t.Errorf("Expected only patched fields, got %s", rt.body)
+	}
+}
+
+func TestStreamSaveIfUnmodified(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"name":"cam1","updated_at":1700000000000}`}
+	client := createTestClient(t, rt)
+	body := central.NewStreamPatch().Set(func(s model.CentralStreamConfig) {
+		s.SetTitle("Entrance")
+	}).Model()
+
+	_, err := central.StreamSaveIfUnmodified(ctx, client, "cam1", 1690000000000, body)
+	if !errors.Is(err, central.ErrModified) {
+		t.Fatalf("Expected ErrModified, got %v", err)
+	}
+	if rt.request.Method != http.MethodGet {
+		t.Errorf("Expected no save of a modified stream, got %s", rt.request.Method)
+	}
+
+	if _, err := central.StreamSaveIfUnmodified(ctx, client, "cam1", 1700000000000, body); err != nil {
+		t.Fatalf("StreamSaveIfUnmodified failed: %v", err)
+	}
+	if rt.request.Method != http.MethodPut {
+		t.Errorf("Expected save of an unmodified stream, got %s", rt.request.Method)
+	}
+	if string(rt.body) != `{"title":"Entrance"}` {
+		t.Errorf("Expected only patched fields, got %s", rt.body)
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string
@@ -0,0 +1,30 @@
+package central
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	model "github.com/flussonic/go-flussonic/central/model"
+)
+
+//go:generate go run ../internal/patchgen -model github.com/flussonic/go-flussonic/central/model "Stream CentralStreamConfig a stream" "AuthBackend AuthBackendConfig an auth backend"
+
+// ErrModified is returned by conditional saves when the resource was changed
+// after the expected version.
+var ErrModified = errors.New("resource was modified")
+
+// StreamSaveIfUnmodified saves the stream only if its updated_at equals updatedAt,
+// the value of a previously fetched stream, and returns ErrModified otherwise.
+// Central has no conditional update, so the stream is checked with StreamGet right
+// before saving, which narrows but does not close the window for concurrent changes.
+func StreamSaveIfUnmodified(ctx context.Context, client Central, name string, updatedAt model.UtcMs, body model.CentralStreamConfig) (model.CentralStreamConfig, error) {
+	current, err := client.StreamGet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if current.UpdatedAt() != updatedAt {
+		return nil, fmt.Errorf("stream %s updated at %d, expected %d: %w", name, current.UpdatedAt(), updatedAt, ErrModified)
+	}
+	return client.StreamSave(ctx, name, body)
+}
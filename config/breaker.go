@@ -0,0 +1,49 @@
+package config
+
+import "time"
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCooldown         = 30 * time.Second
+	DefaultBreakerHalfOpenRequests = 1
+)
+
+// CircuitState is the state of a per-host circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests fast until cooldown expires
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through
+	CircuitHalfOpen
+)
+
+// String returns a human readable state name.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker configures a circuit breaker kept by each client for its BaseURL.
+// Transport errors and 5xx responses are counted as failures.
+// Zero values of the fields are replaced with defaults.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+	// Cooldown is the time the circuit stays open before probing the host again
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of concurrent probe requests allowed in half-open state
+	HalfOpenRequests int
+	// OnStateChange is called after the circuit for baseURL changes its state
+	OnStateChange func(baseURL string, from, to CircuitState)
+}
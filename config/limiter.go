@@ -0,0 +1,99 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limiter throttles outgoing requests. A single Limiter may be shared
+// between several clients pointing at the same host.
+type Limiter interface {
+	// Acquire blocks until the request is allowed or ctx is done.
+	// The returned release function must be called when the request is finished.
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+// TokenBucketLimiter combines a token bucket rate limit with a cap on in-flight requests.
+type TokenBucketLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	slots  chan struct{}
+}
+
+// NewLimiter creates a Limiter allowing ratePerSecond requests per second with the given burst
+// and at most maxInFlight concurrent requests. Zero rate or maxInFlight disables the respective limit.
+func NewLimiter(ratePerSecond float64, burst, maxInFlight int) *TokenBucketLimiter {
+	l := &TokenBucketLimiter{
+		rate:  ratePerSecond,
+		burst: float64(max(burst, 1)),
+	}
+	l.tokens = l.burst
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Acquire implements Limiter.
+func (l *TokenBucketLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for in-flight slot: %w", ctx.Err())
+		}
+	}
+
+	if err := l.waitToken(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// waitToken reserves a token and waits until it becomes available.
+func (l *TokenBucketLimiter) waitToken(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		// Give the reservation back, it can not be used before the deadline
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("rate limit wait %s exceeds context deadline: %w", wait, context.DeadlineExceeded)
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("waiting for rate limit: %w", ctx.Err())
+	}
+}
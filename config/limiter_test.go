@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/config"
+)
+
+func TestLimiter_Rate(t *testing.T) {
+	t.Parallel()
+	limiter := config.NewLimiter(20, 1, 0)
+	ctx := context.Background()
+
+	started := time.Now()
+	for range 5 {
+		release, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		release()
+	}
+	// First token is available immediately, next four wait 50ms each
+	require.GreaterOrEqual(t, time.Since(started), 180*time.Millisecond)
+}
+
+func TestLimiter_Burst(t *testing.T) {
+	t.Parallel()
+	limiter := config.NewLimiter(1, 5, 0)
+	ctx := context.Background()
+
+	started := time.Now()
+	for range 5 {
+		release, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		release()
+	}
+	require.Less(t, time.Since(started), 500*time.Millisecond)
+}
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	t.Parallel()
+	limiter := config.NewLimiter(0, 0, 2)
+	ctx := context.Background()
+
+	var current, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			release, err := limiter.Acquire(ctx)
+			require.NoError(t, err)
+			defer release()
+			n := current.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			current.Add(-1)
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(2), peak.Load())
+}
+
+func TestLimiter_Deadline(t *testing.T) {
+	t.Parallel()
+	limiter := config.NewLimiter(0.1, 1, 0)
+
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = limiter.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(started), 50*time.Millisecond, "should fail fast when wait exceeds deadline")
+}
+
+func TestLimiter_SlotCanceled(t *testing.T) {
+	t.Parallel()
+	limiter := config.NewLimiter(0, 0, 1)
+
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = limiter.Acquire(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,364 @@
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flussonic/go-flussonic/authorization"
+)
+
+// Products are the names of clients a configuration is loaded for.
+// The upper-cased name with dashes replaced by underscores is the prefix
+// of environment variables, e.g. FLUSSONIC_URL or WATCHER_CLIENT_URL.
+const (
+	ProductFlussonic            = "flussonic"
+	ProductCentral              = "central"
+	ProductVisionInference      = "vision-inference"
+	ProductVisionIdentification = "vision-identification"
+	ProductWatcherAdmin         = "watcher-admin"
+	ProductWatcherClient        = "watcher-client"
+)
+
+const (
+	// EnvConfigFile is the environment variable with the path of the profile file.
+	EnvConfigFile = "FLUSSONIC_CONFIG"
+	// EnvContext is the environment variable selecting the context of the profile file.
+	EnvContext = "FLUSSONIC_CONTEXT"
+)
+
+// ErrNotConfigured is returned by Load if neither environment nor profile has an URL for the product.
+var ErrNotConfigured = errors.New("client is not configured")
+
+// Profile is a YAML or JSON file with named contexts, e.g. one per environment.
+// Each context holds endpoints by product name:
+//
+//	current-context: production
+//	contexts:
+//	  production:
+//	    flussonic:
+//	      url: https://streamer.example.com
+//	      cluster_key_file: /var/run/secrets/flussonic/cluster_key
+//	      ca_file: /etc/ssl/private-ca.pem
+//	      retry: 3
+//	      timeout: 30s
+//	    central:
+//	      url: https://central.example.com
+//	      token_file: /var/run/secrets/central/token
+type Profile struct {
+	CurrentContext string                          `yaml:"current-context" json:"current-context"`
+	Contexts       map[string]map[string]*Endpoint `yaml:"contexts" json:"contexts"`
+}
+
+// Endpoint is the configuration of a single client. Secrets may be given directly
+// or read from files, e.g. Kubernetes secret mounts. Values set directly take precedence
+// over files, the first of cluster key, token and username is used.
+type Endpoint struct {
+	// URL in ParseURL format, it may contain the authorization
+	URL string `yaml:"url" json:"url"`
+	// ClusterKey enables cluster key authorization
+	ClusterKey     string `yaml:"cluster_key" json:"cluster_key"`
+	ClusterKeyFile string `yaml:"cluster_key_file" json:"cluster_key_file"`
+	// Token enables bearer authorization
+	Token     string `yaml:"token" json:"token"`
+	TokenFile string `yaml:"token_file" json:"token_file"`
+	// Username and Password enable basic authorization
+	Username     string `yaml:"username" json:"username"`
+	Password     string `yaml:"password" json:"password"`
+	PasswordFile string `yaml:"password_file" json:"password_file"`
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system ones
+	CAFile string `yaml:"ca_file" json:"ca_file"`
+	// CertFile and KeyFile are the PEM client certificate and key for mutual TLS
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	// ServerName overrides the host name the server certificate is verified against
+	ServerName string `yaml:"server_name" json:"server_name"`
+	// Insecure disables server certificate verification
+	Insecure bool `yaml:"insecure" json:"insecure"`
+	// Proxy is the URL of the HTTP proxy, proxy environment variables are used if empty
+	Proxy string `yaml:"proxy" json:"proxy"`
+	// Retry is the maximum number of retries after the initial attempt
+	Retry *uint `yaml:"retry" json:"retry"`
+	// Timeout limits every request including reading the response body, no limit if zero
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+	// Originator is sent in X-Originator header
+	Originator string `yaml:"originator" json:"originator"`
+}
+
+// loadOptions are settings of Load.
+type loadOptions struct {
+	file    string
+	context string
+	getenv  func(string) string
+}
+
+// LoadOption configures Load.
+type LoadOption func(o *loadOptions)
+
+// WithProfile reads the profile from path instead of the file named by FLUSSONIC_CONFIG.
+func WithProfile(path string) LoadOption {
+	return func(o *loadOptions) {
+		o.file = path
+	}
+}
+
+// WithContext selects the profile context instead of FLUSSONIC_CONTEXT or current-context of the file.
+func WithContext(name string) LoadOption {
+	return func(o *loadOptions) {
+		o.context = name
+	}
+}
+
+// WithEnv replaces os.Getenv used to read environment variables.
+func WithEnv(getenv func(string) string) LoadOption {
+	return func(o *loadOptions) {
+		o.getenv = getenv
+	}
+}
+
+// Load returns the configuration of the product client (e.g. ProductFlussonic).
+// The endpoint is read from the profile file if one is given with WithProfile or FLUSSONIC_CONFIG,
+// then environment variables override its fields:
+//
+//	<PRODUCT>_URL, <PRODUCT>_CLUSTER_KEY, <PRODUCT>_CLUSTER_KEY_FILE,
+//	<PRODUCT>_TOKEN, <PRODUCT>_TOKEN_FILE, <PRODUCT>_USERNAME,
+//	<PRODUCT>_PASSWORD, <PRODUCT>_PASSWORD_FILE, <PRODUCT>_CA_FILE,
+//	<PRODUCT>_CERT_FILE, <PRODUCT>_KEY_FILE, <PRODUCT>_SERVER_NAME, <PRODUCT>_INSECURE,
+//	<PRODUCT>_PROXY, <PRODUCT>_RETRY, <PRODUCT>_TIMEOUT, <PRODUCT>_ORIGINATOR
+func Load(product string, opts ...LoadOption) (*Config, error) {
+	options := loadOptions{getenv: os.Getenv}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.file == "" {
+		options.file = options.getenv(EnvConfigFile)
+	}
+	if options.context == "" {
+		options.context = options.getenv(EnvContext)
+	}
+
+	endpoint := &Endpoint{}
+	if options.file != "" {
+		profile, err := ReadProfile(options.file)
+		if err != nil {
+			return nil, err
+		}
+		if endpoint, err = profile.Endpoint(options.context, product); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := endpoint.applyEnv(envPrefix(product), options.getenv); err != nil {
+		return nil, err
+	}
+	if endpoint.URL == "" {
+		return nil, fmt.Errorf("%w: %s URL is not set", ErrNotConfigured, product)
+	}
+	return endpoint.Config()
+}
+
+// ReadProfile reads a YAML or JSON profile file. Unknown fields are rejected.
+func ReadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	profile := &Profile{}
+	if err := decoder.Decode(profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile %s: %w", path, err)
+	}
+
+	// Relative secret and CA paths are resolved against the profile directory
+	dir := filepath.Dir(path)
+	for _, endpoints := range profile.Contexts {
+		for _, endpoint := range endpoints {
+			if endpoint != nil {
+				endpoint.resolvePaths(dir)
+			}
+		}
+	}
+	return profile, nil
+}
+
+// Endpoint returns the product endpoint of the named context, current-context is used if name is empty.
+func (p *Profile) Endpoint(name, product string) (*Endpoint, error) {
+	if name == "" {
+		name = p.CurrentContext
+	}
+	if name == "" {
+		return nil, fmt.Errorf("profile context is not selected")
+	}
+	endpoints, ok := p.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("profile context %q not found", name)
+	}
+	endpoint, ok := endpoints[product]
+	if !ok || endpoint == nil {
+		return &Endpoint{}, nil
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+// Config builds the client configuration of the endpoint reading secret files.
+func (e *Endpoint) Config() (*Config, error) {
+	cfg, err := ParseURL(e.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterKey, err := secret(e.ClusterKey, e.ClusterKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster key: %w", err)
+	}
+	token, err := secret(e.Token, e.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token: %w", err)
+	}
+	password, err := secret(e.Password, e.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password: %w", err)
+	}
+
+	switch {
+	case clusterKey != "":
+		cfg.Auth = authorization.ClusterKey(clusterKey)
+	case token != "":
+		cfg.Auth = authorization.BearerAuth(token)
+	case e.Username != "":
+		cfg.Auth = authorization.BasicAuth(e.Username, password)
+	}
+
+	if e.Retry != nil {
+		cfg.Retry = *e.Retry
+	}
+	if e.Originator != "" {
+		cfg.Originator = e.Originator
+	}
+
+	if e.CAFile != "" || e.CertFile != "" || e.KeyFile != "" || e.ServerName != "" || e.Insecure {
+		if cfg.TLS == nil {
+			cfg.TLS = &TLS{}
+		}
+		cfg.TLS.CAFile = cmp.Or(e.CAFile, cfg.TLS.CAFile)
+		cfg.TLS.CertFile = cmp.Or(e.CertFile, cfg.TLS.CertFile)
+		cfg.TLS.KeyFile = cmp.Or(e.KeyFile, cfg.TLS.KeyFile)
+		cfg.TLS.ServerName = cmp.Or(e.ServerName, cfg.TLS.ServerName)
+		cfg.TLS.InsecureSkipVerify = cfg.TLS.InsecureSkipVerify || e.Insecure
+	}
+	if e.Proxy != "" {
+		if cfg.Transport == nil {
+			cfg.Transport = &Transport{}
+		}
+		cfg.Transport.ProxyURL = e.Proxy
+	}
+	if e.Timeout > 0 {
+		cfg.Timeout = e.Timeout
+	}
+
+	// Fail early on unreadable certificates instead of at client creation
+	if _, err := cfg.BuildHTTPClient(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides fields with environment variables having prefix.
+// Authorization from the environment replaces the authorization of the profile.
+func (e *Endpoint) applyEnv(prefix string, getenv func(string) string) error {
+	auth := map[string]*string{
+		"CLUSTER_KEY":      &e.ClusterKey,
+		"CLUSTER_KEY_FILE": &e.ClusterKeyFile,
+		"TOKEN":            &e.Token,
+		"TOKEN_FILE":       &e.TokenFile,
+		"USERNAME":         &e.Username,
+		"PASSWORD":         &e.Password,
+		"PASSWORD_FILE":    &e.PasswordFile,
+	}
+	for suffix := range auth {
+		if getenv(prefix+suffix) != "" {
+			for _, field := range auth {
+				*field = ""
+			}
+			break
+		}
+	}
+
+	fields := map[string]*string{
+		"URL":         &e.URL,
+		"CA_FILE":     &e.CAFile,
+		"CERT_FILE":   &e.CertFile,
+		"KEY_FILE":    &e.KeyFile,
+		"SERVER_NAME": &e.ServerName,
+		"PROXY":       &e.Proxy,
+		"ORIGINATOR":  &e.Originator,
+	}
+	maps.Copy(fields, auth)
+	for suffix, field := range fields {
+		if value := getenv(prefix + suffix); value != "" {
+			*field = value
+		}
+	}
+
+	if value := getenv(prefix + "RETRY"); value != "" {
+		retry, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid %sRETRY: %w", prefix, err)
+		}
+		r := uint(retry)
+		e.Retry = &r
+	}
+	if value := getenv(prefix + "INSECURE"); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %sINSECURE: %w", prefix, err)
+		}
+		e.Insecure = insecure
+	}
+	if value := getenv(prefix + "TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %sTIMEOUT: %w", prefix, err)
+		}
+		e.Timeout = timeout
+	}
+	return nil
+}
+
+// resolvePaths makes relative file paths relative to dir.
+func (e *Endpoint) resolvePaths(dir string) {
+	for _, path := range []*string{&e.ClusterKeyFile, &e.TokenFile, &e.PasswordFile, &e.CAFile, &e.CertFile, &e.KeyFile} {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, *path)
+		}
+	}
+}
+
+// envPrefix returns the environment variable prefix of product, e.g. WATCHER_CLIENT_.
+func envPrefix(product string) string {
+	return strings.ToUpper(strings.ReplaceAll(product, "-", "_")) + "_"
+}
+
+// secret returns value or the trimmed content of file.
+func secret(value, file string) (string, error) {
+	if value != "" || file == "" {
+		return value, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,204 @@
+package config_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/authorization"
+	"github.com/flussonic/go-flussonic/config"
+)
+
+const profile = `
+current-context: staging
+contexts:
+  staging:
+    flussonic:
+      url: http://streamer.staging.local
+      cluster_key_file: secrets/cluster_key
+      retry: 2
+    central:
+      url: https://central.staging.local
+      token: central-token
+      timeout: 15s
+      proxy: http://proxy.local:3128
+  production:
+    flussonic:
+      url: https://streamer.example.com:8443
+      username: admin
+      password_file: secrets/password
+`
+
+// writeProfile writes the profile with secret files to a temporary directory.
+func writeProfile(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "secrets"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets", "cluster_key"), []byte("file-key\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets", "password"), []byte("file-password\n"), 0o600))
+	path := filepath.Join(dir, "profile.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// env returns getenv reading vars.
+func env(vars map[string]string) config.LoadOption {
+	return config.WithEnv(func(name string) string { return vars[name] })
+}
+
+func TestLoad_Env(t *testing.T) {
+	t.Parallel()
+	cfg, err := config.Load(config.ProductFlussonic, env(map[string]string{
+		"FLUSSONIC_URL":         "https://streamer.example.com",
+		"FLUSSONIC_CLUSTER_KEY": "secret",
+		"FLUSSONIC_RETRY":       "3",
+		"FLUSSONIC_ORIGINATOR":  "ops",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "streamer.example.com", cfg.Hostname)
+	require.Equal(t, 443, cfg.Port)
+	require.Equal(t, uint(3), cfg.Retry)
+	require.Equal(t, "ops", cfg.Originator)
+	require.NoError(t, authorization.VerifyClusterKey(cfg.Auth.ToHeader(), "secret", 0))
+	require.Nil(t, cfg.TLS)
+	require.Nil(t, cfg.Transport)
+
+	cfg, err = config.Load(config.ProductWatcherClient, env(map[string]string{
+		"WATCHER_CLIENT_URL":     "http://watcher.local",
+		"WATCHER_CLIENT_TOKEN":   "token",
+		"WATCHER_CLIENT_TIMEOUT": "5s",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, authorization.BearerAuth("token"), cfg.Auth)
+	require.Equal(t, 5*time.Second, cfg.Timeout)
+}
+
+func TestLoad_Profile(t *testing.T) {
+	t.Parallel()
+	path := writeProfile(t, profile)
+
+	cfg, err := config.Load(config.ProductFlussonic, config.WithProfile(path), env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "streamer.staging.local", cfg.Hostname)
+	require.Equal(t, uint(2), cfg.Retry)
+	require.NoError(t, authorization.VerifyClusterKey(cfg.Auth.ToHeader(), "file-key", 0))
+
+	cfg, err = config.Load(config.ProductCentral, config.WithProfile(path), env(nil))
+	require.NoError(t, err)
+	require.Equal(t, authorization.BearerAuth("central-token"), cfg.Auth)
+	require.Equal(t, 15*time.Second, cfg.Timeout)
+	require.Equal(t, "http://proxy.local:3128", cfg.Transport.ProxyURL)
+
+	// Context is selected by option or environment
+	cfg, err = config.Load(config.ProductFlussonic, config.WithProfile(path), config.WithContext("production"), env(nil))
+	require.NoError(t, err)
+	require.Equal(t, 8443, cfg.Port)
+	require.Equal(t, authorization.BasicAuth("admin", "file-password"), cfg.Auth)
+
+	cfg, err = config.Load(config.ProductFlussonic, env(map[string]string{
+		config.EnvConfigFile: path,
+		config.EnvContext:    "production",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "streamer.example.com", cfg.Hostname)
+
+	// Environment overrides the profile
+	cfg, err = config.Load(config.ProductFlussonic, config.WithProfile(path), env(map[string]string{
+		"FLUSSONIC_URL":   "http://override.local",
+		"FLUSSONIC_TOKEN": "env-token",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "override.local", cfg.Hostname)
+	require.Equal(t, uint(2), cfg.Retry)
+	require.Equal(t, authorization.BearerAuth("env-token"), cfg.Auth)
+}
+
+func TestLoad_JSONProfile(t *testing.T) {
+	t.Parallel()
+	path := writeProfile(t, `{"current-context":"lab","contexts":{"lab":{"central":{"url":"http://central.lab","username":"u","password":"p"}}}}`)
+
+	cfg, err := config.Load(config.ProductCentral, config.WithProfile(path), env(nil))
+	require.NoError(t, err)
+	require.Equal(t, authorization.BasicAuth("u", "p"), cfg.Auth)
+}
+
+func TestLoad_CAFile(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	cfg, err := config.Load(config.ProductFlussonic, env(map[string]string{
+		"FLUSSONIC_URL":     "https://streamer.example.com",
+		"FLUSSONIC_CA_FILE": caFile,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, &config.TLS{CAFile: caFile}, cfg.TLS)
+
+	cfg, err = config.Load(config.ProductFlussonic, env(map[string]string{
+		"FLUSSONIC_URL":         "https://streamer.example.com?ca=" + caFile,
+		"FLUSSONIC_SERVER_NAME": "streamer.local",
+		"FLUSSONIC_INSECURE":    "true",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, &config.TLS{CAFile: caFile, ServerName: "streamer.local", InsecureSkipVerify: true}, cfg.TLS)
+
+	_, err = config.Load(config.ProductFlussonic, env(map[string]string{
+		"FLUSSONIC_URL":     "https://streamer.example.com",
+		"FLUSSONIC_CA_FILE": filepath.Join(t.TempDir(), "missing.pem"),
+	}))
+	require.Error(t, err)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := config.Load(config.ProductFlussonic, env(nil))
+	require.ErrorIs(t, err, config.ErrNotConfigured)
+
+	path := writeProfile(t, profile)
+	_, err = config.Load(config.ProductFlussonic, config.WithProfile(path), config.WithContext("missing"), env(nil))
+	require.Error(t, err)
+
+	// Product missing in the context is not configured
+	_, err = config.Load(config.ProductVisionInference, config.WithProfile(path), env(nil))
+	require.ErrorIs(t, err, config.ErrNotConfigured)
+
+	for name, vars := range map[string]map[string]string{
+		"retry":       {"FLUSSONIC_URL": "http://s", "FLUSSONIC_RETRY": "many"},
+		"timeout":     {"FLUSSONIC_URL": "http://s", "FLUSSONIC_TIMEOUT": "10"},
+		"insecure":    {"FLUSSONIC_URL": "http://s", "FLUSSONIC_INSECURE": "maybe"},
+		"cert":        {"FLUSSONIC_URL": "https://s", "FLUSSONIC_CERT_FILE": "/nonexistent/cert.pem"},
+		"scheme":      {"FLUSSONIC_URL": "rtmp://s"},
+		"query":       {"FLUSSONIC_URL": "http://s?key=1"},
+		"secret file": {"FLUSSONIC_URL": "http://s", "FLUSSONIC_TOKEN_FILE": "/nonexistent/token"},
+		"profile":     {config.EnvConfigFile: "/nonexistent/profile.yaml"},
+	} {
+		_, err := config.Load(config.ProductFlussonic, env(vars))
+		require.Error(t, err, name)
+	}
+
+	unknown := writeProfile(t, "contexts:\n  lab:\n    flussonic:\n      url: http://s\n      clusterkey: typo\n")
+	_, err = config.Load(config.ProductFlussonic, config.WithProfile(unknown), config.WithContext("lab"), env(nil))
+	require.ErrorContains(t, err, "clusterkey")
+}
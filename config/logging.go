@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultLogBodyLimit is the maximum number of body bytes added to a log record.
+	DefaultLogBodyLimit = 4096
+	// RedactedValue replaces masked values in log records.
+	RedactedValue = "***"
+)
+
+// Redaction defines which data is masked in log records.
+// Authorization header, cluster keys and password fields are always masked.
+type Redaction struct {
+	// Headers are additional header names to mask, matched case-insensitively
+	Headers []string
+	// Fields are additional JSON object keys to mask in bodies, matched case-insensitively
+	Fields []string
+	// MaxBodySize limits the logged body size, DefaultLogBodyLimit if zero
+	MaxBodySize int
+}
+
+// MaskHeader returns the header value with sensitive data masked.
+// The authorization scheme is kept, so it is visible which auth type was used.
+func (r *Redaction) MaskHeader(name, value string) string {
+	if strings.EqualFold(name, "Authorization") {
+		if scheme, _, ok := strings.Cut(value, " "); ok {
+			return scheme + " " + RedactedValue
+		}
+		return RedactedValue
+	}
+	if r != nil && slices.ContainsFunc(r.Headers, func(header string) bool { return strings.EqualFold(header, name) }) {
+		return RedactedValue
+	}
+	return value
+}
+
+// MaskHeaders returns a copy of header with sensitive values masked.
+func (r *Redaction) MaskHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		result[name] = r.MaskHeader(name, strings.Join(values, ", "))
+	}
+	return result
+}
+
+// MaskBody returns the body prepared for logging: sensitive JSON fields are masked
+// and the result is truncated to MaxBodySize. Non-JSON bodies are not logged
+// as they can not be masked, only their size is reported.
+func (r *Redaction) MaskBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[%d bytes of non-JSON body]", len(body))
+	}
+	masked, err := json.Marshal(r.mask(value))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes of body]", len(body))
+	}
+
+	limit := DefaultLogBodyLimit
+	if r != nil && r.MaxBodySize > 0 {
+		limit = r.MaxBodySize
+	}
+	if len(masked) > limit {
+		return string(masked[:limit]) + "..."
+	}
+	return string(masked)
+}
+
+// mask replaces values of sensitive keys in decoded JSON.
+func (r *Redaction) mask(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.sensitiveField(key) {
+				v[key] = RedactedValue
+			} else {
+				v[key] = r.mask(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.mask(item)
+		}
+	}
+	return value
+}
+
+func (r *Redaction) sensitiveField(key string) bool {
+	key = strings.ToLower(key)
+	if strings.Contains(key, "password") || key == "cluster_key" {
+		return true
+	}
+	return r != nil && slices.ContainsFunc(r.Fields, func(field string) bool { return strings.EqualFold(field, key) })
+}
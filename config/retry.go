@@ -0,0 +1,140 @@
+package config
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryInitialDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay     = 30 * time.Second
+	DefaultRetryMultiplier   = 2.0
+	DefaultRetryJitter       = 0.2
+)
+
+// RetryAttempt describes the outcome of a single request attempt
+// passed to RetryPolicy to decide whether the request should be repeated.
+type RetryAttempt struct {
+	// Method is the HTTP method of the request
+	Method string
+	// Attempt is the 1-based number of the attempt that has just finished
+	Attempt int
+	// StatusCode is the response status code, 0 if no response was received
+	StatusCode int
+	// Err is the transport or body read error, nil if a response was received
+	Err error
+	// RetryAfter is the delay requested by the server via Retry-After header, 0 if absent
+	RetryAfter time.Duration
+	// IdempotencyKey is the key sent with the request, it makes any method safe to repeat
+	IdempotencyKey string
+}
+
+// RetryPolicy decides per attempt whether a request should be retried and how long to wait.
+// The total number of attempts is still limited by Config.Retry.
+type RetryPolicy interface {
+	// ShouldRetry returns true and the delay before the next attempt if the request should be retried
+	ShouldRetry(attempt RetryAttempt) (retry bool, delay time.Duration)
+}
+
+// ExponentialBackoff is a RetryPolicy that retries transport errors, 5xx and 429 responses
+// with exponentially growing delays. Retry-After is honored on 429 and 503 responses
+// up to MaxDelay. Zero values of the fields are replaced with defaults.
+type ExponentialBackoff struct {
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay caps the computed delay and the delay requested by Retry-After
+	MaxDelay time.Duration
+	// Multiplier is the growth factor between consecutive delays
+	Multiplier float64
+	// Jitter is the fraction of the delay [0..1] that is randomized
+	Jitter float64
+	// NoJitter disables randomization, so delays are deterministic
+	NoJitter bool
+	// RetryNonIdempotent allows retrying POST and PATCH requests without idempotency key
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used when Config.RetryPolicy is not set.
+func DefaultRetryPolicy() RetryPolicy {
+	return &ExponentialBackoff{}
+}
+
+// ShouldRetry implements RetryPolicy.
+func (b *ExponentialBackoff) ShouldRetry(attempt RetryAttempt) (retry bool, delay time.Duration) {
+	if !b.RetryNonIdempotent && !IsIdempotent(attempt.Method) && attempt.IdempotencyKey == "" {
+		return false, 0
+	}
+
+	switch {
+	case attempt.Err != nil:
+	case attempt.StatusCode == http.StatusTooManyRequests, attempt.StatusCode >= 500:
+		if attempt.RetryAfter > 0 &&
+			(attempt.StatusCode == http.StatusTooManyRequests || attempt.StatusCode == http.StatusServiceUnavailable) {
+			return true, min(attempt.RetryAfter, b.maxDelay())
+		}
+	default:
+		return false, 0
+	}
+
+	return true, b.Delay(attempt.Attempt)
+}
+
+// Delay returns the backoff delay after the given 1-based attempt number.
+func (b *ExponentialBackoff) Delay(attempt int) time.Duration {
+	initial := b.InitialDelay
+	if initial <= 0 {
+		initial = DefaultRetryInitialDelay
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultRetryMultiplier
+	}
+	jitter := b.Jitter
+	if jitter <= 0 || jitter > 1 {
+		jitter = DefaultRetryJitter
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	delay = min(delay, float64(b.maxDelay()))
+	if !b.NoJitter {
+		// nolint:gosec
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+func (b *ExponentialBackoff) maxDelay() time.Duration {
+	if b.MaxDelay <= 0 {
+		return DefaultRetryMaxDelay
+	}
+	return b.MaxDelay
+}
+
+// IsIdempotent reports whether requests with the given method may be safely repeated.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodConnect:
+		return false
+	default:
+		return true
+	}
+}
+
+// ParseRetryAfter parses Retry-After header value given in seconds or as HTTP date.
+// Returns 0 if the value is empty or invalid.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/config"
+)
+
+func TestExponentialBackoff_ShouldRetry(t *testing.T) {
+	t.Parallel()
+	policy := &config.ExponentialBackoff{InitialDelay: 100 * time.Millisecond, Jitter: 1}
+
+	tests := []struct {
+		name    string
+		attempt config.RetryAttempt
+		retry   bool
+	}{
+		{"transport error", config.RetryAttempt{Method: http.MethodGet, Attempt: 1, Err: errors.New("boom")}, true},
+		{"server error", config.RetryAttempt{Method: http.MethodPut, Attempt: 1, StatusCode: 502}, true},
+		{"too many requests", config.RetryAttempt{Method: http.MethodGet, Attempt: 1, StatusCode: 429}, true},
+		{"client error", config.RetryAttempt{Method: http.MethodGet, Attempt: 1, StatusCode: 404}, false},
+		{"post server error", config.RetryAttempt{Method: http.MethodPost, Attempt: 1, StatusCode: 503}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, delay := policy.ShouldRetry(tt.attempt)
+			require.Equal(t, tt.retry, retry)
+			require.LessOrEqual(t, delay, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestExponentialBackoff_RetryAfter(t *testing.T) {
+	t.Parallel()
+	policy := &config.ExponentialBackoff{}
+
+	retry, delay := policy.ShouldRetry(config.RetryAttempt{
+		Method: http.MethodGet, Attempt: 1, StatusCode: http.StatusServiceUnavailable, RetryAfter: 7 * time.Second,
+	})
+	require.True(t, retry)
+	require.Equal(t, 7*time.Second, delay)
+
+	// Retry-After is capped by MaxDelay
+	policy = &config.ExponentialBackoff{MaxDelay: 10 * time.Second}
+	retry, delay = policy.ShouldRetry(config.RetryAttempt{
+		Method: http.MethodGet, Attempt: 1, StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Hour,
+	})
+	require.True(t, retry)
+	require.Equal(t, 10*time.Second, delay)
+}
+
+func TestExponentialBackoff_Delay(t *testing.T) {
+	t.Parallel()
+	policy := &config.ExponentialBackoff{InitialDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5}
+
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 5 * time.Second} {
+		delay := policy.Delay(attempt)
+		require.LessOrEqual(t, delay, expected)
+		require.GreaterOrEqual(t, delay, expected/2)
+	}
+}
+
+func TestExponentialBackoff_NoJitter(t *testing.T) {
+	t.Parallel()
+	policy := &config.ExponentialBackoff{InitialDelay: time.Second, MaxDelay: 5 * time.Second, NoJitter: true}
+
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 5 * time.Second} {
+		require.Equal(t, expected, policy.Delay(attempt))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, 3*time.Second, config.ParseRetryAfter("3", now))
+	require.Equal(t, 10*time.Second, config.ParseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+	require.Zero(t, config.ParseRetryAfter("", now))
+	require.Zero(t, config.ParseRetryAfter("soon", now))
+	require.Zero(t, config.ParseRetryAfter("-5", now))
+}
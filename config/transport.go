@@ -0,0 +1,142 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// TLS configures certificates of HTTPS connections.
+type TLS struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system ones
+	CAFile string
+	// CA is a PEM bundle like CAFile given inline
+	CA []byte
+	// CertFile and KeyFile are the PEM client certificate and key for mutual TLS
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the host name the server certificate is verified against
+	ServerName string
+	// InsecureSkipVerify disables server certificate verification, use only with lab servers
+	// having self-signed certificates
+	InsecureSkipVerify bool
+}
+
+// Transport tunes connections to the server.
+type Transport struct {
+	// ProxyURL is the URL of the HTTP proxy, proxy environment variables are used if empty
+	ProxyURL string
+	// DialTimeout limits establishing a TCP connection, 30s if zero
+	DialTimeout time.Duration
+	// DisableHTTP2 forces HTTP/1.1 for HTTPS connections
+	DisableHTTP2 bool
+	// MaxIdleConns limits idle connections to all hosts, 100 if zero
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections to the server, 2 if zero
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes idle connections after the timeout, 90s if zero
+	IdleConnTimeout time.Duration
+}
+
+// BuildHTTPClient returns HTTPClient if it is set, otherwise a new client built from
+// TLS, Transport and Timeout. It returns nil if none of them is set, so the default client is used.
+func (c *Config) BuildHTTPClient() (*http.Client, error) {
+	if c.HTTPClient != nil {
+		return c.HTTPClient, nil
+	}
+	if c.TLS == nil && c.Transport == nil && c.Timeout == 0 {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.build()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if c.Transport != nil {
+		if err := c.Transport.apply(transport); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Client{Transport: transport, Timeout: c.Timeout}, nil
+}
+
+// build returns the TLS client configuration.
+func (t *TLS) build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: t.ServerName,
+		// nolint:gosec // explicitly requested for servers with self-signed certificates
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	ca := t.CA
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		ca = append(append([]byte{}, ca...), pem...)
+	}
+	if len(ca) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		if t.CertFile == "" || t.KeyFile == "" {
+			return nil, fmt.Errorf("both client certificate and key files are required")
+		}
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// apply sets connection settings on transport.
+func (t *Transport) apply(transport *http.Transport) error {
+	if t.ProxyURL != "" {
+		proxy, err := url.Parse(t.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if t.DialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: t.DialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if t.DisableHTTP2 {
+		protocols := &http.Protocols{}
+		protocols.SetHTTP1(true)
+		transport.Protocols = protocols
+		transport.ForceAttemptHTTP2 = false
+	}
+	if t.MaxIdleConns > 0 {
+		transport.MaxIdleConns = t.MaxIdleConns
+	}
+	if t.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	}
+	if t.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = t.IdleConnTimeout
+	}
+	return nil
+}
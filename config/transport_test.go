@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/config"
+)
+
+// writeServerCA writes the certificate of the TLS test server as a CA bundle.
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	return path
+}
+
+// writeClientCertificate writes a self-signed client certificate and key and returns them with the certificate.
+func writeClientCertificate(t *testing.T) (certFile, keyFile string, certificate *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile, certificate
+}
+
+// get sends a request to server with the client built from cfg.
+func get(t *testing.T, cfg *config.Config, server *httptest.Server) (*http.Response, error) {
+	t.Helper()
+	client, err := cfg.BuildHTTPClient()
+	require.NoError(t, err)
+	response, err := client.Get(server.URL)
+	if err == nil {
+		_ = response.Body.Close()
+	}
+	return response, err
+}
+
+func TestBuildHTTPClient_Default(t *testing.T) {
+	t.Parallel()
+	client, err := (&config.Config{}).BuildHTTPClient()
+	require.NoError(t, err)
+	require.Nil(t, client)
+
+	custom := &http.Client{}
+	client, err = (&config.Config{HTTPClient: custom, Timeout: time.Second}).BuildHTTPClient()
+	require.NoError(t, err)
+	require.Same(t, custom, client)
+}
+
+func TestBuildHTTPClient_CA(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Self-signed certificate is rejected by default
+	_, err := get(t, &config.Config{Timeout: 5 * time.Second}, server)
+	require.Error(t, err)
+
+	response, err := get(t, &config.Config{TLS: &config.TLS{CAFile: writeServerCA(t, server)}}, server)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response, err = get(t, &config.Config{TLS: &config.TLS{InsecureSkipVerify: true}}, server)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestBuildHTTPClient_ClientCertificate(t *testing.T) {
+	t.Parallel()
+	certFile, keyFile, certificate := writeClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writeServerCA(t, server)
+
+	_, err := get(t, &config.Config{TLS: &config.TLS{CAFile: caFile}}, server)
+	require.Error(t, err)
+
+	response, err := get(t, &config.Config{TLS: &config.TLS{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}}, server)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestBuildHTTPClient_HTTP2(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	caFile := writeServerCA(t, server)
+
+	response, err := get(t, &config.Config{TLS: &config.TLS{CAFile: caFile}}, server)
+	require.NoError(t, err)
+	require.Equal(t, 2, response.ProtoMajor)
+
+	response, err = get(t, &config.Config{
+		TLS:       &config.TLS{CAFile: caFile},
+		Transport: &config.Transport{DisableHTTP2: true, DialTimeout: time.Second, MaxIdleConnsPerHost: 4},
+	}, server)
+	require.NoError(t, err)
+	require.Equal(t, 1, response.ProtoMajor)
+}
+
+func TestBuildHTTPClient_Errors(t *testing.T) {
+	t.Parallel()
+	invalidCA := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0o600))
+
+	for name, cfg := range map[string]*config.Config{
+		"missing ca":  {TLS: &config.TLS{CAFile: "/nonexistent/ca.pem"}},
+		"invalid ca":  {TLS: &config.TLS{CAFile: invalidCA}},
+		"cert only":   {TLS: &config.TLS{CertFile: invalidCA}},
+		"invalid key": {TLS: &config.TLS{CertFile: invalidCA, KeyFile: invalidCA}},
+		"proxy":       {Transport: &config.Transport{ProxyURL: "http://proxy:port"}},
+	} {
+		_, err := cfg.BuildHTTPClient()
+		require.Error(t, err, name)
+	}
+}
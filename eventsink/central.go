@@ -0,0 +1,24 @@
+package eventsink
+
+import (
+	model "github.com/flussonic/go-flussonic/central/model"
+)
+
+// registerCentralEvents registers models of events sent by Central.
+func registerCentralEvents(r *Receiver) {
+	r.Register("agent_command_handling", func() Event { return &model.AgentCommandHandlingImpl{} })
+	r.Register("agent_connect_failed", func() Event { return &model.AgentConnectFailedImpl{} })
+	r.Register("agent_connected", func() Event { return &model.AgentConnectedImpl{} })
+	r.Register("agent_disconnected", func() Event { return &model.AgentDisconnectedImpl{} })
+	r.Register("api_call", func() Event { return &model.APICallEventImpl{} })
+	r.Register("database_error", func() Event { return &model.DatabaseErrorImpl{} })
+	r.Register("layouter_entity_fetched", func() Event { return &model.LayouterEntityFetchedImpl{} })
+	r.Register("layouter_fetching_started", func() Event { return &model.LayouterFetchingStartedImpl{} })
+	r.Register("layouter_process_agents_error", func() Event { return &model.LayouterProcessAgentsErrorImpl{} })
+	r.Register("layouter_process_agents_success", func() Event { return &model.LayouterProcessAgentsSuccessImpl{} })
+	r.Register("layouter_process_streams_error", func() Event { return &model.LayouterProcessStreamsErrorImpl{} })
+	r.Register("layouter_process_streams_success", func() Event { return &model.LayouterProcessStreamsSuccessImpl{} })
+	r.Register("layouter_processing_result", func() Event { return &model.LayouterProcessingResultImpl{} })
+	r.Register("server_started", func() Event { return &model.ServerStartedImpl{} })
+	r.Register("server_starting", func() Event { return &model.ServerStartingImpl{} })
+}
@@ -0,0 +1,232 @@
+// Package eventsink receives events posted by Flussonic and Central event sinks
+// configured with an HTTP URL, decodes them into model types by the event field
+// and dispatches them to handlers.
+//
+// A Receiver decodes Central events. Events of Flussonic media servers are decoded
+// after registering them with flussonic.RegisterEvents, otherwise they are
+// passed to handlers as Unknown.
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBodySize limits the size of a posted batch if Receiver.MaxBodySize is zero.
+const DefaultMaxBodySize = 16 << 20
+
+// ErrBusy is returned by channel handlers when the channel stays full, the batch
+// is rejected with 503 so the sink delivers it again later.
+var ErrBusy = errors.New("event channel is full")
+
+// Event is implemented by all event models, e.g. central model.ServerStarted.
+type Event interface {
+	// Event returns the event name, e.g. server_started
+	Event() string
+}
+
+// Unknown is an event with a name not registered in the Receiver.
+type Unknown struct {
+	Name string
+	Raw  json.RawMessage
+}
+
+// Event returns the event name.
+func (u *Unknown) Event() string {
+	return u.Name
+}
+
+// HandlerFunc handles a decoded event. Returning an error rejects the batch,
+// so the sink delivers it again.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Receiver is an http.Handler accepting batches of JSON events.
+type Receiver struct {
+	// MaxBodySize limits the size of a posted batch, DefaultMaxBodySize if zero
+	MaxBodySize int64
+	// Logger receives errors of malformed batches and failed handlers if set
+	Logger *slog.Logger
+
+	mu       sync.RWMutex
+	types    map[string]func() Event
+	handlers []HandlerFunc
+}
+
+// NewReceiver creates a Receiver decoding Central events.
+// Events of Flussonic media servers are registered with flussonic.RegisterEvents,
+// events of other products with Register.
+func NewReceiver() *Receiver {
+	r := &Receiver{types: map[string]func() Event{}}
+	registerCentralEvents(r)
+	return r
+}
+
+// Register sets the model created for events with the given name.
+func (r *Receiver) Register(name string, newEvent func() Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[name] = newEvent
+}
+
+// HandleFunc registers handler called for every event in order of registration.
+func (r *Receiver) HandleFunc(handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+// Handle registers handler called for events of type T, e.g. model.AgentConnected.
+func Handle[T Event](r *Receiver, handler func(ctx context.Context, event T) error) {
+	r.HandleFunc(func(ctx context.Context, event Event) error {
+		if typed, ok := event.(T); ok {
+			return handler(ctx, typed)
+		}
+		return nil
+	})
+}
+
+// Chan returns a channel receiving all events. The channel has size buffered events,
+// when it is full requests are blocked applying back-pressure to the sink and
+// rejected with ErrBusy after timeout. Zero timeout blocks until the request is canceled.
+// The channel is never closed.
+func (r *Receiver) Chan(size int, timeout time.Duration) <-chan Event {
+	events := make(chan Event, size)
+	r.HandleFunc(func(ctx context.Context, event Event) error {
+		select {
+		case events <- event:
+			return nil
+		default:
+		}
+
+		var expired <-chan time.Time
+		if timeout > 0 {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			expired = timer.C
+		}
+		select {
+		case events <- event:
+			return nil
+		case <-expired:
+			return ErrBusy
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return events
+}
+
+// Decode decodes a batch posted by a sink, a JSON array of events or a single event.
+func (r *Receiver) Decode(data []byte) ([]Event, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		var single json.RawMessage
+		if json.Unmarshal(data, &single) != nil || len(single) == 0 || single[0] != '{' {
+			return nil, fmt.Errorf("failed to decode events: %w", err)
+		}
+		raws = []json.RawMessage{single}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	events := make([]Event, 0, len(raws))
+	for i, raw := range raws {
+		var header struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d: %w", i, err)
+		}
+		if header.Event == "" {
+			return nil, fmt.Errorf("event %d has no event field", i)
+		}
+
+		newEvent, ok := r.types[header.Event]
+		if !ok {
+			events = append(events, &Unknown{Name: header.Event, Raw: raw})
+			continue
+		}
+		event := newEvent()
+		if err := json.Unmarshal(raw, event); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event: %w", header.Event, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Dispatch calls handlers for events in order and stops at the first error.
+func (r *Receiver) Dispatch(ctx context.Context, events []Event) error {
+	r.mu.RLock()
+	handlers := r.handlers
+	r.mu.RUnlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				return fmt.Errorf("failed to handle %s event: %w", event.Event(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// ServeHTTP decodes a posted batch and dispatches its events.
+// Malformed batches are rejected with 400, handler errors with 500
+// and ErrBusy with 503, so the sink retries them. Events of a retried batch dispatched
+// before the failure are delivered again, so handlers should tolerate duplicates.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBodySize := r.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, request.Body, maxBodySize))
+	if err != nil {
+		r.log(request.Context(), "failed to read events", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body is too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+		}
+		return
+	}
+
+	events, err := r.Decode(data)
+	if err != nil {
+		r.log(request.Context(), "invalid events", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.Dispatch(request.Context(), events); err != nil {
+		r.log(request.Context(), "failed to handle events", err)
+		if errors.Is(err, ErrBusy) {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "events are not accepted now", http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, "failed to handle events", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Receiver) log(ctx context.Context, msg string, err error) {
+	if r.Logger != nil {
+		r.Logger.WarnContext(ctx, msg, slog.Any("error", err))
+	}
+}
@@ -0,0 +1,162 @@
+package eventsink_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/eventsink"
+)
+
+const batch = `[
+	{"event":"server_started","event_id":1,"server":"central.local","version":"24.10","utc_ms":1700000000000},
+	{"event":"agent_connected","event_id":2,"entity_type":"agent","entity_id":"agent-1"},
+	{"event":"layouter_process_streams_success","event_id":3},
+	{"event":"stream_opened","media":"cam1"}
+]`
+
+func post(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestReceiver_Dispatch(t *testing.T) {
+	t.Parallel()
+	receiver := eventsink.NewReceiver()
+
+	var names []string
+	receiver.HandleFunc(func(ctx context.Context, event eventsink.Event) error {
+		names = append(names, event.Event())
+		return nil
+	})
+	var started model.ServerStarted
+	eventsink.Handle(receiver, func(ctx context.Context, event model.ServerStarted) error {
+		started = event
+		return nil
+	})
+	var agents []string
+	eventsink.Handle(receiver, func(ctx context.Context, event model.AgentConnected) error {
+		agents = append(agents, *event.EntityID())
+		return nil
+	})
+	var layouter int
+	eventsink.Handle(receiver, func(ctx context.Context, event model.LayouterProcessStreamsSuccess) error {
+		layouter++
+		return nil
+	})
+	var unknown *eventsink.Unknown
+	eventsink.Handle(receiver, func(ctx context.Context, event *eventsink.Unknown) error {
+		unknown = event
+		return nil
+	})
+
+	response := post(receiver, batch)
+	require.Equal(t, http.StatusNoContent, response.Code)
+
+	require.Equal(t, []string{"server_started", "agent_connected", "layouter_process_streams_success", "stream_opened"}, names)
+	require.Equal(t, "24.10", *started.Version())
+	require.Equal(t, "central.local", *started.Server())
+	require.Equal(t, []string{"agent-1"}, agents)
+	require.Equal(t, 1, layouter)
+	require.Equal(t, "stream_opened", unknown.Name)
+	require.JSONEq(t, `{"event":"stream_opened","media":"cam1"}`, string(unknown.Raw))
+}
+
+func TestReceiver_SingleEvent(t *testing.T) {
+	t.Parallel()
+	receiver := eventsink.NewReceiver()
+	var started int
+	eventsink.Handle(receiver, func(ctx context.Context, event model.ServerStarted) error {
+		started++
+		return nil
+	})
+
+	response := post(receiver, `{"event":"server_started"}`)
+	require.Equal(t, http.StatusNoContent, response.Code)
+	require.Equal(t, 1, started)
+}
+
+type streamOpened struct {
+	EventValue string `json:"event"`
+	Media      string `json:"media"`
+}
+
+func (s *streamOpened) Event() string {
+	return s.EventValue
+}
+
+func TestReceiver_Register(t *testing.T) {
+	t.Parallel()
+	receiver := eventsink.NewReceiver()
+	receiver.Register("stream_opened", func() eventsink.Event { return &streamOpened{} })
+
+	events, err := receiver.Decode([]byte(batch))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, &streamOpened{EventValue: "stream_opened", Media: "cam1"}, events[3])
+}
+
+func TestReceiver_Errors(t *testing.T) {
+	t.Parallel()
+	receiver := eventsink.NewReceiver()
+	receiver.MaxBodySize = 1024
+	receiver.HandleFunc(func(ctx context.Context, event eventsink.Event) error {
+		if event.Event() == "database_error" {
+			return errors.New("storage failed")
+		}
+		return nil
+	})
+
+	for body, status := range map[string]int{
+		`not json`:         http.StatusBadRequest,
+		`[1]`:              http.StatusBadRequest,
+		`[{"event_id":1}]`: http.StatusBadRequest,
+		`"server_started"`: http.StatusBadRequest,
+		`[{"event":"server_started","event_id":"x"}]`: http.StatusBadRequest,
+		`[{"event":"database_error"}]`:                http.StatusInternalServerError,
+		`[` + strings.Repeat(" ", 2048) + `]`:         http.StatusRequestEntityTooLarge,
+	} {
+		require.Equal(t, status, post(receiver, body).Code, body)
+	}
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", http.NoBody))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestReceiver_Chan(t *testing.T) {
+	t.Parallel()
+	receiver := eventsink.NewReceiver()
+	events := receiver.Chan(2, 50*time.Millisecond)
+
+	// Batch larger than the buffer waits for the consumer
+	var wg sync.WaitGroup
+	var code int
+	wg.Go(func() {
+		code = post(receiver, batch).Code
+	})
+	var names []string
+	for range 4 {
+		names = append(names, (<-events).Event())
+	}
+	wg.Wait()
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, []string{"server_started", "agent_connected", "layouter_process_streams_success", "stream_opened"}, names)
+
+	// Full channel rejects the batch after timeout
+	response := post(receiver, batch)
+	require.Equal(t, http.StatusServiceUnavailable, response.Code)
+	require.Equal(t, "1", response.Header().Get("Retry-After"))
+	require.Len(t, events, 2)
+}
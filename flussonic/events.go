@@ -0,0 +1,59 @@
+package flussonic
+
+import (
+	"github.com/flussonic/go-flussonic/eventsink"
+	model "github.com/flussonic/go-flussonic/flussonic/model"
+)
+
+// RegisterEvents registers models of events sent by Flussonic media servers in r,
+// so they are decoded into flussonic model types instead of eventsink.Unknown.
+// Events with the same names as Central events, e.g. server_started, are decoded
+// into Flussonic models after the call.
+func RegisterEvents(r *eventsink.Receiver) {
+	r.Register("ad_injected", func() eventsink.Event { return &model.AdInjectedImpl{} })
+	r.Register("api_call", func() eventsink.Event { return &model.APICallEventImpl{} })
+	r.Register("change_server_id", func() eventsink.Event { return &model.ChangeServerIDImpl{} })
+	r.Register("chassis_connected", func() eventsink.Event { return &model.ChassisConnectedImpl{} })
+	r.Register("chassis_disconnected", func() eventsink.Event { return &model.ChassisDisconnectedImpl{} })
+	r.Register("config_external_error", func() eventsink.Event { return &model.ConfigExternalErrorImpl{} })
+	r.Register("config_external_success", func() eventsink.Event { return &model.ConfigExternalSuccessImpl{} })
+	r.Register("dvr_blob_deleted", func() eventsink.Event { return &model.DvrBlobDeletedImpl{} })
+	r.Register("dvr_clean", func() eventsink.Event { return &model.DvrCleanImpl{} })
+	r.Register("dvr_collapsed_write", func() eventsink.Event { return &model.DvrCollapsedWriteImpl{} })
+	r.Register("dvr_consistency_check_finished", func() eventsink.Event { return &model.DvrConsistencyCheckFinishedImpl{} })
+	r.Register("dvr_deleted_fragments", func() eventsink.Event { return &model.DvrDeletedFragmentsImpl{} })
+	r.Register("dvr_drop_write", func() eventsink.Event { return &model.DvrDropWriteImpl{} })
+	r.Register("dvr_export_finished", func() eventsink.Event { return &model.DvrExportFinishedImpl{} })
+	r.Register("dvr_new_fragment", func() eventsink.Event { return &model.DvrNewFragmentImpl{} })
+	r.Register("motion_started", func() eventsink.Event { return &model.MotionStartedImpl{} })
+	r.Register("motion_stopped", func() eventsink.Event { return &model.MotionStoppedImpl{} })
+	r.Register("multiplexer_pid_off", func() eventsink.Event { return &model.MultiplexerPidOffImpl{} })
+	r.Register("multiplexer_pid_on", func() eventsink.Event { return &model.MultiplexerPidOnImpl{} })
+	r.Register("play_closed", func() eventsink.Event { return &model.PlayClosedImpl{} })
+	r.Register("play_opened", func() eventsink.Event { return &model.PlayOpenedImpl{} })
+	r.Register("play_started", func() eventsink.Event { return &model.PlayStartedImpl{} })
+	r.Register("play_updated", func() eventsink.Event { return &model.PlayUpdatedImpl{} })
+	r.Register("presence_connected", func() eventsink.Event { return &model.PresenceConnectedImpl{} })
+	r.Register("presence_disconnected", func() eventsink.Event { return &model.PresenceDisconnectedImpl{} })
+	r.Register("push_blocked", func() eventsink.Event { return &model.PushBlockedImpl{} })
+	r.Register("push_closed", func() eventsink.Event { return &model.PushClosedImpl{} })
+	r.Register("push_connected", func() eventsink.Event { return &model.PushConnectedImpl{} })
+	r.Register("push_opened", func() eventsink.Event { return &model.PushOpenedImpl{} })
+	r.Register("push_reconfigured", func() eventsink.Event { return &model.PushReconfiguredImpl{} })
+	r.Register("push_restored", func() eventsink.Event { return &model.PushRestoredImpl{} })
+	r.Register("push_started", func() eventsink.Event { return &model.PushStartedImpl{} })
+	r.Register("push_updated", func() eventsink.Event { return &model.PushUpdatedImpl{} })
+	r.Register("server_started", func() eventsink.Event { return &model.ServerStartedImpl{} })
+	r.Register("server_starting", func() eventsink.Event { return &model.ServerStartingImpl{} })
+	r.Register("source_closed", func() eventsink.Event { return &model.SourceClosedImpl{} })
+	r.Register("source_connected", func() eventsink.Event { return &model.SourceConnectedImpl{} })
+	r.Register("source_opened", func() eventsink.Event { return &model.SourceOpenedImpl{} })
+	r.Register("source_started", func() eventsink.Event { return &model.SourceStartedImpl{} })
+	r.Register("source_updated", func() eventsink.Event { return &model.SourceUpdatedImpl{} })
+	r.Register("stream_closed", func() eventsink.Event { return &model.StreamClosedImpl{} })
+	r.Register("stream_opened", func() eventsink.Event { return &model.StreamOpenedImpl{} })
+	r.Register("stream_updated", func() eventsink.Event { return &model.StreamUpdatedImpl{} })
+	r.Register("transcoder_device_insufficient_resources", func() eventsink.Event { return &model.TranscoderDeviceInsufficientResourcesImpl{} })
+	r.Register("webrtc_play_prober_timeout", func() eventsink.Event { return &model.WebrtcPlayProberTimeoutImpl{} })
+	r.Register("webrtc_play_prober_wrong_interval", func() eventsink.Event { return &model.WebrtcPlayProberWrongIntervalImpl{} })
+}
@@ -0,0 +1,56 @@
+package flussonic_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flussonic/go-flussonic/eventsink"
+	flussonic "github.com/flussonic/go-flussonic/flussonic"
+	model "github.com/flussonic/go-flussonic/flussonic/model"
+)
+
+func TestRegisterEvents(t *testing.T) {
+	receiver := eventsink.NewReceiver()
+	flussonic.RegisterEvents(receiver)
+
+	var opened []string
+	eventsink.Handle(receiver, func(ctx context.Context, event model.StreamOpened) error {
+		opened = append(opened, *event.Media())
+		return nil
+	})
+	var started int
+	eventsink.Handle(receiver, func(ctx context.Context, event model.ServerStarted) error {
+		started++
+		return nil
+	})
+	var unknown []string
+	eventsink.Handle(receiver, func(ctx context.Context, event *eventsink.Unknown) error {
+		unknown = append(unknown, event.Name)
+		return nil
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`[
+		{"event":"stream_opened","media":"cam1"},
+		{"event":"server_started","server":"streamer1.local"},
+		{"event":"unknown_event"}
+	]`))
+	request.Header.Set("Content-Type", "application/json")
+	receiver.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("Expected success, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(opened) != 1 || opened[0] != "cam1" {
+		t.Errorf("Expected stream_opened for cam1, got %v", opened)
+	}
+	if started != 1 {
+		t.Errorf("Expected server_started decoded into Flussonic model, got %d", started)
+	}
+	if len(unknown) != 1 || unknown[0] != "unknown_event" {
+		t.Errorf("Expected only unknown_event to be unknown, got %v", unknown)
+	}
+}
@@ -0,0 +1,263 @@
+package flussonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flussonic/go-flussonic/apierror"
+	"github.com/flussonic/go-flussonic/central"
+	centralmodel "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/config"
+	model "github.com/flussonic/go-flussonic/flussonic/model"
+)
+
+// DefaultFleetConcurrency is the number of servers called at once if WithConcurrency is not used.
+const DefaultFleetConcurrency = 16
+
+// Fleet runs the same operation on many Flussonic servers.
+type Fleet struct {
+	servers     []string
+	clients     map[string]Flussonic
+	concurrency int
+	timeout     time.Duration
+}
+
+// FleetOption configures a Fleet.
+type FleetOption func(*Fleet)
+
+// WithConcurrency limits the number of servers called at once.
+func WithConcurrency(concurrency int) FleetOption {
+	return func(f *Fleet) {
+		if concurrency > 0 {
+			f.concurrency = concurrency
+		}
+	}
+}
+
+// WithServerTimeout limits the time of an operation on a single server,
+// a slow server fails with context.DeadlineExceeded without delaying the others.
+func WithServerTimeout(timeout time.Duration) FleetOption {
+	return func(f *Fleet) {
+		f.timeout = timeout
+	}
+}
+
+// NewFleet creates a Fleet with a client for every configuration.
+// Servers are named hostname:port.
+func NewFleet(configs []*config.Config, opts ...FleetOption) (*Fleet, error) {
+	clients := make(map[string]Flussonic, len(configs))
+	for _, cfg := range configs {
+		client, err := New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client of %s: %w", cfg.Hostname, err)
+		}
+		server := serverName(cfg)
+		if _, ok := clients[server]; ok {
+			return nil, fmt.Errorf("duplicate server %s", server)
+		}
+		clients[server] = client
+	}
+	return NewFleetWithClients(clients, opts...), nil
+}
+
+// NewFleetWithClients creates a Fleet of existing clients keyed by server name.
+func NewFleetWithClients(clients map[string]Flussonic, opts ...FleetOption) *Fleet {
+	f := &Fleet{
+		clients:     make(map[string]Flussonic, len(clients)),
+		concurrency: DefaultFleetConcurrency,
+	}
+	for server, client := range clients {
+		f.servers = append(f.servers, server)
+		f.clients[server] = client
+	}
+	slices.Sort(f.servers)
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// DiscoverFleet creates a Fleet of media servers registered in Central.
+// Every server is configured with a copy of template, where the address is taken from
+// the api_url of the streamer or its hostname. The streamer cluster key is used
+// if template has no credentials. Template settings like Limiter are shared by all servers.
+func DiscoverFleet(ctx context.Context, c central.Central, template *config.Config, opts ...FleetOption) (*Fleet, error) {
+	if template == nil {
+		template = &config.Config{}
+	}
+	httpClient, err := template.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+
+	var configs []*config.Config
+	for streamer, err := range c.StreamersListIterator(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list streamers: %w", err)
+		}
+		if role := streamer.Role(); role != nil && *role != centralmodel.CentralNodeRoleRoleStreamer {
+			continue
+		}
+		cfg, err := streamerConfig(streamer, template)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			continue
+		}
+		cfg.HTTPClient = httpClient
+		configs = append(configs, cfg)
+	}
+	return NewFleet(configs, opts...)
+}
+
+// streamerConfig returns the configuration of a streamer registered in Central
+// or nil if the streamer has no address.
+func streamerConfig(streamer centralmodel.StreamerConfig, template *config.Config) (*config.Config, error) {
+	cfg := *template
+	switch {
+	case streamer.APIURL() != nil && *streamer.APIURL() != "":
+		parsed, err := config.ParseURL(string(*streamer.APIURL()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid api_url of streamer: %w", err)
+		}
+		cfg.Protocol = parsed.Protocol
+		cfg.Hostname = parsed.Hostname
+		cfg.Port = parsed.Port
+		if parsed.Auth != nil {
+			cfg.Auth = parsed.Auth
+		}
+	case streamer.Hostname() != nil && *streamer.Hostname() != "":
+		cfg.Hostname = string(*streamer.Hostname())
+	default:
+		return nil, nil
+	}
+
+	if cfg.Auth == nil && cfg.ClusterKey == "" && streamer.ClusterKey() != nil {
+		cfg.ClusterKey = *streamer.ClusterKey()
+	}
+	return &cfg, nil
+}
+
+// serverName returns hostname:port of a configured client.
+func serverName(cfg *config.Config) string {
+	return cfg.Hostname + ":" + strconv.Itoa(cfg.Port)
+}
+
+// Servers returns names of servers in the fleet in sorted order.
+func (f *Fleet) Servers() []string {
+	return append([]string(nil), f.servers...)
+}
+
+// Client returns the client of the server.
+func (f *Fleet) Client(server string) (Flussonic, bool) {
+	client, ok := f.clients[server]
+	return client, ok
+}
+
+// FleetResult is the outcome of an operation on a single server.
+type FleetResult[T any] struct {
+	Server string
+	Value  T
+	Err    error
+}
+
+// FleetResults are outcomes of an operation on all servers in the order of Fleet.Servers.
+type FleetResults[T any] []FleetResult[T]
+
+// Values returns values of servers where the operation succeeded.
+func (r FleetResults[T]) Values() map[string]T {
+	values := make(map[string]T, len(r))
+	for _, result := range r {
+		if result.Err == nil {
+			values[result.Server] = result.Value
+		}
+	}
+	return values
+}
+
+// Failed returns results of servers where the operation failed.
+func (r FleetResults[T]) Failed() FleetResults[T] {
+	var failed FleetResults[T]
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err joins errors of all failed servers prefixed with server names, nil if all succeeded.
+func (r FleetResults[T]) Err() error {
+	var errs []error
+	for _, result := range r {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Server, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FanOut calls op for every server of the fleet with bounded concurrency and returns
+// per-server results. Servers not called before ctx is done fail with its error.
+func FanOut[T any](ctx context.Context, f *Fleet, op func(ctx context.Context, client Flussonic) (T, error)) FleetResults[T] {
+	results := make(FleetResults[T], len(f.servers))
+	slots := make(chan struct{}, f.concurrency)
+	var wg sync.WaitGroup
+	for i, server := range f.servers {
+		results[i].Server = server
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			serverCtx := ctx
+			if f.timeout > 0 {
+				var cancel context.CancelFunc
+				serverCtx, cancel = context.WithTimeout(ctx, f.timeout)
+				defer cancel()
+			}
+			results[i].Value, results[i].Err = op(serverCtx, f.clients[server])
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// Do calls op for every server of the fleet like FanOut for operations without a result.
+func (f *Fleet) Do(ctx context.Context, op func(ctx context.Context, client Flussonic) error) FleetResults[struct{}] {
+	return FanOut(ctx, f, func(ctx context.Context, client Flussonic) (struct{}, error) {
+		return struct{}{}, op(ctx, client)
+	})
+}
+
+// ConfigStatsGet gets runtime statistics of every server.
+func (f *Fleet) ConfigStatsGet(ctx context.Context) FleetResults[model.ServerStats] {
+	return FanOut(ctx, f, func(ctx context.Context, client Flussonic) (model.ServerStats, error) {
+		return client.ConfigStatsGet(ctx)
+	})
+}
+
+// StreamStop stops the stream on every server where it runs. The value is true
+// for servers where the stream was stopped and false where it is not configured.
+func (f *Fleet) StreamStop(ctx context.Context, name string) FleetResults[bool] {
+	return FanOut(ctx, f, func(ctx context.Context, client Flussonic) (bool, error) {
+		err := client.StreamStop(ctx, name)
+		if apierror.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
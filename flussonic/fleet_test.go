@@ -0,0 +1,228 @@
+package flussonic_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flussonic/go-flussonic/authorization"
+	"github.com/flussonic/go-flussonic/central"
+	"github.com/flussonic/go-flussonic/config"
+	flussonic "github.com/flussonic/go-flussonic/flussonic"
+)
+
+// testServer starts a streamer answering stats with its bandwidth usage
+// and stopping only the streams in running.
+func testServer(t *testing.T, bandwidth int, running ...string) (*httptest.Server, *config.Config) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/streamer/api/v3/config/stats":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"bandwidth_usage":%d}`, bandwidth)
+		case strings.HasSuffix(r.URL.Path, "/stop"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/streamer/api/v3/streams/"), "/stop")
+			for _, stream := range running {
+				if stream == name {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+			http.Error(w, `{"errors":[{"status":"404","title":"not found"}]}`, http.StatusNotFound)
+		default:
+			http.Error(w, "unexpected request", http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg, err := config.ParseURL(server.URL)
+	if err != nil {
+		t.Fatalf("ParseURL failed: %v", err)
+	}
+	return server, cfg
+}
+
+func serverNameOf(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestFleet_ConfigStatsGet(t *testing.T) {
+	ctx := context.Background()
+	server1, cfg1 := testServer(t, 10)
+	server2, cfg2 := testServer(t, 20)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	t.Cleanup(failing.Close)
+	cfg3, err := config.ParseURL(failing.URL)
+	if err != nil {
+		t.Fatalf("ParseURL failed: %v", err)
+	}
+
+	fleet, err := flussonic.NewFleet([]*config.Config{cfg1, cfg2, cfg3}, flussonic.WithConcurrency(2))
+	if err != nil {
+		t.Fatalf("NewFleet failed: %v", err)
+	}
+	if len(fleet.Servers()) != 3 {
+		t.Fatalf("Expected 3 servers, got %v", fleet.Servers())
+	}
+
+	results := fleet.ConfigStatsGet(ctx)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	values := results.Values()
+	for server, bandwidth := range map[string]int{serverNameOf(t, server1): 10, serverNameOf(t, server2): 20} {
+		stats, ok := values[server]
+		if !ok {
+			t.Fatalf("No stats of %s", server)
+		}
+		encoded, err := json.Marshal(stats)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !strings.Contains(string(encoded), fmt.Sprintf(`"bandwidth_usage":%d`, bandwidth)) {
+			t.Errorf("Unexpected stats of %s: %s", server, encoded)
+		}
+	}
+
+	failed := results.Failed()
+	if len(failed) != 1 || failed[0].Server != serverNameOf(t, failing) {
+		t.Fatalf("Expected failure of %s, got %v", failing.URL, failed)
+	}
+	if err := results.Err(); err == nil || !strings.Contains(err.Error(), serverNameOf(t, failing)) {
+		t.Errorf("Expected error naming the failed server, got %v", err)
+	}
+}
+
+func TestFleet_StreamStop(t *testing.T) {
+	ctx := context.Background()
+	server1, cfg1 := testServer(t, 0, "cam1")
+	server2, cfg2 := testServer(t, 0, "cam2")
+
+	fleet, err := flussonic.NewFleet([]*config.Config{cfg1, cfg2})
+	if err != nil {
+		t.Fatalf("NewFleet failed: %v", err)
+	}
+
+	results := fleet.StreamStop(ctx, "cam1")
+	if err := results.Err(); err != nil {
+		t.Fatalf("StreamStop failed: %v", err)
+	}
+	values := results.Values()
+	if !values[serverNameOf(t, server1)] || values[serverNameOf(t, server2)] {
+		t.Errorf("Expected stream stopped only on %s, got %v", server1.URL, values)
+	}
+}
+
+func TestFleet_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	clients := map[string]flussonic.Flussonic{}
+	for i := range 10 {
+		clients[fmt.Sprintf("server%d:80", i)] = nil
+	}
+	fleet := flussonic.NewFleetWithClients(clients, flussonic.WithConcurrency(3), flussonic.WithServerTimeout(20*time.Millisecond))
+
+	var running, peak atomic.Int32
+	results := flussonic.FanOut(ctx, fleet, func(ctx context.Context, client flussonic.Flussonic) (int32, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			observed := peak.Load()
+			if current <= observed || peak.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		<-ctx.Done()
+		return current, ctx.Err()
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("Expected at most 3 concurrent calls, got %d", peak.Load())
+	}
+	if len(results.Failed()) != 10 {
+		t.Fatalf("Expected all servers to time out, got %d failures", len(results.Failed()))
+	}
+	for _, result := range results {
+		if !errors.Is(result.Err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded for %s, got %v", result.Server, result.Err)
+		}
+	}
+}
+
+func TestFleet_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fleet := flussonic.NewFleetWithClients(map[string]flussonic.Flussonic{"a:80": nil, "b:80": nil}, flussonic.WithConcurrency(1))
+
+	var calls atomic.Int32
+	results := fleet.Do(ctx, func(ctx context.Context, client flussonic.Flussonic) error {
+		calls.Add(1)
+		return ctx.Err()
+	})
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected canceled for %s, got %v", result.Server, result.Err)
+		}
+	}
+	if calls.Load() != 0 {
+		t.Errorf("Expected no calls after cancellation, got %d", calls.Load())
+	}
+}
+
+func TestDiscoverFleet(t *testing.T) {
+	ctx := context.Background()
+	var authorizationHeader atomic.Value
+	streamer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizationHeader.Store(r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"bandwidth_usage":5}`)
+	}))
+	t.Cleanup(streamer.Close)
+
+	centralServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/central/api/v3/streamers" {
+			http.Error(w, "unexpected request", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"streamers":[
+			{"hostname":"streamer1.local","api_url":%q,"cluster_key":"secret"},
+			{"hostname":"inference.local","role":"inference"},
+			{"cluster_key":"nowhere"}
+		]}`, streamer.URL)
+	}))
+	t.Cleanup(centralServer.Close)
+	centralConfig, err := config.ParseURL(centralServer.URL)
+	if err != nil {
+		t.Fatalf("ParseURL failed: %v", err)
+	}
+	centralClient, err := central.New(centralConfig)
+	if err != nil {
+		t.Fatalf("central.New failed: %v", err)
+	}
+
+	fleet, err := flussonic.DiscoverFleet(ctx, centralClient, &config.Config{Originator: "ops"})
+	if err != nil {
+		t.Fatalf("DiscoverFleet failed: %v", err)
+	}
+	if servers := fleet.Servers(); len(servers) != 1 || servers[0] != serverNameOf(t, streamer) {
+		t.Fatalf("Expected only %s, got %v", streamer.URL, servers)
+	}
+
+	if err := fleet.ConfigStatsGet(ctx).Err(); err != nil {
+		t.Fatalf("ConfigStatsGet failed: %v", err)
+	}
+	header, _ := authorizationHeader.Load().(string)
+	if err := authorization.VerifyClusterKey(header, "secret", authorization.DefaultClusterKeyWindow); err != nil {
+		t.Errorf("Expected requests signed with the streamer cluster key: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/flussonic/go-flussonic/authorization"
@@ -13,6 +14,7 @@ import (
 	flussonic "github.com/flussonic/go-flussonic/flussonic"
 	model "github.com/flussonic/go-flussonic/flussonic/model"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
+	"github.com/flussonic/go-flussonic/upload"
 )
 
 func TestClient_ApiTokensList(t *testing.T) {
@@ -496,12 +498,12 @@ func TestClient_CameraAlarmSave(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.CameraAlarmSave(ctx)
+	_, err := client.CameraAlarmSave(ctx, &model.CameraAlarmConfigImpl{})
 	if err != nil {
 		t.Fatalf("CameraAlarmSave failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.CameraAlarmSave(ctx)
+	result, err := client.CameraAlarmSave(ctx, &model.CameraAlarmConfigImpl{})
 	if err != nil {
 		t.Fatalf("CameraAlarmSave failed: %v", err)
 	}
@@ -772,12 +774,12 @@ func TestClient_DiskFileSave(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.DiskFileSave(ctx, "test-prefix", "test-storage_index", "test-subpath")
+	_, err := client.DiskFileSave(ctx, "test-prefix", "test-storage_index", "test-subpath", strings.NewReader("test-content"))
 	if err != nil {
 		t.Fatalf("DiskFileSave failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.DiskFileSave(ctx, "test-prefix", "test-storage_index", "test-subpath")
+	result, err := client.DiskFileSave(ctx, "test-prefix", "test-storage_index", "test-subpath", strings.NewReader("test-content"))
 	if err != nil {
 		t.Fatalf("DiskFileSave failed: %v", err)
 	}
@@ -2425,7 +2427,7 @@ func TestClient_MultiplexerXmltvUpload(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	err := client.MultiplexerXmltvUpload(ctx, "test-name")
+	err := client.MultiplexerXmltvUpload(ctx, "test-name", strings.NewReader("<tv></tv>"))
 	if err != nil {
 		t.Fatalf("MultiplexerXmltvUpload failed: %v", err)
 	}
@@ -5020,12 +5022,12 @@ func TestClient_VisionSave(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.VisionSave(ctx)
+	_, err := client.VisionSave(ctx, &model.VisionConfigImpl{})
 	if err != nil {
 		t.Fatalf("VisionSave failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.VisionSave(ctx)
+	result, err := client.VisionSave(ctx, &model.VisionConfigImpl{})
 	if err != nil {
 		t.Fatalf("VisionSave failed: %v", err)
 	}
@@ -5303,6 +5305,342 @@ func TestClient_VsaasSave(t *testing.T) {
 	verifyMarshalUnmarshal(t, result, expectedJSON)
 }
 
+func TestClient_SessionsReauthSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &flussonic.SessionsReauthQuery{Name: "test-name"}
+	if _, err := client.SessionsReauth(ctx, query); err != nil {
+		t.Fatalf("SessionsReauth failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("name"); got != "test-name" {
+		t.Errorf("Expected query name=test-name, got %q", got)
+	}
+
+	if _, err := client.SessionsReauth(ctx, nil); err == nil {
+		t.Error("Expected error for missing required query")
+	}
+}
+
+func TestClient_StreamSaveMp4SendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &flussonic.StreamSaveMp4Query{Duration: 60, From: 1700000000, Path: "/storage/export.mp4", Timelapse: true}
+	if err := client.StreamSaveMp4(ctx, "test-name", query); err != nil {
+		t.Fatalf("StreamSaveMp4 failed: %v", err)
+	}
+	values := rt.request.URL.Query()
+	expected := map[string]string{"duration": "60", "from": "1700000000", "path": "/storage/export.mp4", "timelapse": "true"}
+	for key, value := range expected {
+		if got := values.Get(key); got != value {
+			t.Errorf("Expected query %s=%s, got %q", key, value, got)
+		}
+	}
+}
+
+func TestClient_StreamDvrRangesDeleteSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	body := model.NewDvrRange()
+	body.SetFrom(model.Utc(1700000000))
+	body.SetDuration(model.Seconds(3600))
+	if err := client.StreamDvrRangesDelete(ctx, "test-name", body); err != nil {
+		t.Fatalf("StreamDvrRangesDelete failed: %v", err)
+	}
+	if rt.request.Method != http.MethodDelete {
+		t.Errorf("Expected method DELETE, got %s", rt.request.Method)
+	}
+	verifyRequestBody(t, rt.body, `{"from":1700000000,"duration":3600}`)
+}
+
+func TestClient_StreamDvrLocksDeleteSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	body := model.NewDvrRange()
+	body.SetFrom(model.Utc(1700000000))
+	body.SetDuration(model.Seconds(60))
+	if err := client.StreamDvrLocksDelete(ctx, "test-name", body); err != nil {
+		t.Fatalf("StreamDvrLocksDelete failed: %v", err)
+	}
+	verifyRequestBody(t, rt.body, `{"from":1700000000,"duration":60}`)
+}
+
+func TestClient_CameraAlarmSaveSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"catch":["example"]}`}
+	client := createTestClient(t, rt)
+
+	body := &model.CameraAlarmConfigImpl{}
+	if err := json.Unmarshal([]byte(`{"catch":["example"]}`), body); err != nil {
+		t.Fatalf("Failed to unmarshal body: %v", err)
+	}
+	if _, err := client.CameraAlarmSave(ctx, body); err != nil {
+		t.Fatalf("CameraAlarmSave failed: %v", err)
+	}
+	verifyRequestBody(t, rt.body, `{"catch":["example"]}`)
+}
+
+func TestClient_VisionSaveSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"hw":"example"}`}
+	client := createTestClient(t, rt)
+
+	body := &model.VisionConfigImpl{}
+	if err := json.Unmarshal([]byte(`{"hw":"example"}`), body); err != nil {
+		t.Fatalf("Failed to unmarshal body: %v", err)
+	}
+	if _, err := client.VisionSave(ctx, body); err != nil {
+		t.Fatalf("VisionSave failed: %v", err)
+	}
+	verifyRequestBody(t, rt.body, `{"hw":"example"}`)
+}
+
+func TestClient_DiskFileSaveSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"name":"movie.mp4"}`}
+	client := createTestClient(t, rt)
+
+	if _, err := client.DiskFileSave(ctx, "vod", "0", "movie.mp4", strings.NewReader("binary-content")); err != nil {
+		t.Fatalf("DiskFileSave failed: %v", err)
+	}
+	if string(rt.body) != "binary-content" {
+		t.Errorf("Expected raw body, got %q", string(rt.body))
+	}
+	if got := rt.request.Header.Get("Content-Type"); got != "application/octet-stream" {
+		t.Errorf("Expected octet-stream content type, got %q", got)
+	}
+}
+
+func TestClient_MultiplexerXmltvUploadSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	xmltv := `<?xml version="1.0"?><tv></tv>`
+	if err := client.MultiplexerXmltvUpload(ctx, "test-name", strings.NewReader(xmltv)); err != nil {
+		t.Fatalf("MultiplexerXmltvUpload failed: %v", err)
+	}
+	if string(rt.body) != xmltv {
+		t.Errorf("Expected XMLTV body, got %q", string(rt.body))
+	}
+	if got := rt.request.Header.Get("Content-Type"); got != "application/xml" {
+		t.Errorf("Expected XML content type, got %q", got)
+	}
+}
+
+func TestClient_LogoUploadSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"name":"logo.png","content_type":"image/png"}`}
+	client := createTestClient(t, rt)
+
+	content := "\x89PNG\r\n"
+	body := upload.New(strings.NewReader(content), "image/png", int64(len(content)))
+	if _, err := client.LogoUpload(ctx, "logo.png", body); err != nil {
+		t.Fatalf("LogoUpload failed: %v", err)
+	}
+	if rt.request.Method != http.MethodPut {
+		t.Errorf("Expected PUT, got %s", rt.request.Method)
+	}
+	verifyRequestBody(t, rt.body, `{"content":"iVBORw0K","content_type":"image/png"}`)
+	if got := rt.request.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", got)
+	}
+	if rt.request.ContentLength != int64(len(rt.body)) {
+		t.Errorf("Expected content length %d, got %d", len(rt.body), rt.request.ContentLength)
+	}
+}
+
+func TestClient_ConfigGetRaw(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: "stream foo {\n  input fake://fake;\n}\n"}
+	client := createTestClient(t, rt)
+
+	body, err := client.ConfigGetRaw(ctx, &flussonic.ConfigGetQuery{Format: "text"})
+	if err != nil {
+		t.Fatalf("ConfigGetRaw failed: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(data) != "stream foo {\n  input fake://fake;\n}\n" {
+		t.Errorf("Expected raw body, got %q", data)
+	}
+	if got := rt.request.URL.Query().Get("format"); got != "text" {
+		t.Errorf("Expected query format=text, got %q", got)
+	}
+}
+
+func TestClient_StreamsListRaw(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: "name,alive\nfoo,true\n"}
+	client := createTestClient(t, rt)
+
+	body, err := client.StreamsListRaw(ctx, &flussonic.StreamsListQuery{Format: "csv"})
+	if err != nil {
+		t.Fatalf("StreamsListRaw failed: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(data) != "name,alive\nfoo,true\n" {
+		t.Errorf("Expected raw body, got %q", data)
+	}
+	if got := rt.request.URL.Query().Get("format"); got != "csv" {
+		t.Errorf("Expected query format=csv, got %q", got)
+	}
+}
+
+func TestClient_StreamGetEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for name, expected := range map[string]string{
+		"mylive/bunny":      "/streamer/api/v3/streams/mylive%2Fbunny",
+		"my stream":         "/streamer/api/v3/streams/my%20stream",
+		"камера":            "/streamer/api/v3/streams/%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0",
+		"../../config":      "/streamer/api/v3/streams/..%2F..%2Fconfig",
+		"..":                "/streamer/api/v3/streams/%2E%2E",
+		"name?format=csv#x": "/streamer/api/v3/streams/name%3Fformat=csv%23x",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.StreamGet(ctx, name); err != nil {
+			t.Fatalf("StreamGet(%q) failed: %v", name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("StreamGet(%q): expected path %s, got %s", name, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("StreamGet(%q): unexpected query %q", name, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_QueryMethodsEscapeName(t *testing.T) {
+	ctx := context.Background()
+	name := "../live/cam?x=1#y"
+	for _, tt := range []struct {
+		method string
+		path   string
+		query  string
+		call   func(client flussonic.Flussonic) error
+	}{
+		{
+			"StreamSaveMp4",
+			"/streamer/api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y/dvr/export",
+			"duration=60&from=1700000000&meta=false&path=%2Fstorage%2Fcam.mp4&timelapse=false",
+			func(client flussonic.Flussonic) error {
+				return client.StreamSaveMp4(ctx, name, &flussonic.StreamSaveMp4Query{Duration: 60, From: 1700000000, Path: "/storage/cam.mp4"})
+			},
+		},
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if err := tt.call(client); err != nil {
+			t.Fatalf("%s(%q) failed: %v", tt.method, name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != tt.path {
+			t.Errorf("%s(%q): expected path %s, got %s", tt.method, name, tt.path, got)
+		}
+		if rt.request.URL.RawQuery != tt.query {
+			t.Errorf("%s(%q): expected query %q, got %q", tt.method, name, tt.query, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_DiskFileGetKeepsSubpathSlashes(t *testing.T) {
+	ctx := context.Background()
+	for subpath, expected := range map[string]string{
+		"movie.mp4":               "/streamer/api/v3/vods/vod/storages/0/files/movie.mp4",
+		"dir/sub dir/фильм.mp4":   "/streamer/api/v3/vods/vod/storages/0/files/dir/sub%20dir/%D1%84%D0%B8%D0%BB%D1%8C%D0%BC.mp4",
+		"../../../config":         "/streamer/api/v3/vods/vod/storages/0/files/%2E%2E/%2E%2E/%2E%2E/config",
+		"dir/file.mp4?format=csv": "/streamer/api/v3/vods/vod/storages/0/files/dir/file.mp4%3Fformat=csv",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.DiskFileGet(ctx, "vod", "0", subpath); err != nil {
+			t.Fatalf("DiskFileGet(%q) failed: %v", subpath, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("DiskFileGet(%q): expected path %s, got %s", subpath, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("DiskFileGet(%q): unexpected query %q", subpath, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_StreamSavePatch(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"name":"cam1","title":"Entrance"}`}
+	client := createTestClient(t, rt)
+
+	body := flussonic.NewStreamPatch().Set(func(s model.StreamConfig) {
+		s.SetTitle("Entrance")
+	}).Null("/comment", "/dvr/expiration").Model()
+	if _, err := client.StreamSave(ctx, "cam1", body); err != nil {
+		t.Fatalf("StreamSave failed: %v", err)
+	}
+	if string(rt.body) != `{"comment":null,"dvr":{"expiration":null},"title":"Entrance"}` {
+		t.Errorf("Expected only patched fields, got %s", rt.body)
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
+// verifyRequestBody checks that every field of expectedJSON is present in the sent body
+func verifyRequestBody(t *testing.T, body []byte, expectedJSON string) {
+	var expected, actual map[string]any
+	if err := json.Unmarshal([]byte(expectedJSON), &expected); err != nil {
+		t.Fatalf("Failed to unmarshal expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(body, &actual); err != nil {
+		t.Fatalf("Failed to unmarshal request body %q: %v", string(body), err)
+	}
+	for key, value := range expected {
+		expectedValue, _ := json.Marshal(value)
+		actualValue, _ := json.Marshal(actual[key])
+		if !bytes.Equal(expectedValue, actualValue) {
+			t.Errorf("Body key %s: expected %s, got %s", key, expectedValue, actualValue)
+		}
+	}
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string
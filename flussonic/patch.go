@@ -0,0 +1,3 @@
+package flussonic
+
+//go:generate go run ../internal/patchgen -model github.com/flussonic/go-flussonic/flussonic/model "Stream StreamConfig a stream" "AuthBackend AuthBackendConfig an auth backend" "DvbCard DvbCardConfig a DVB card" "DvrDisk RaidDiskConfig a DVR disk" "Dvr DvrConfig a DVR" "Package PackageConfig a package" "Subscriber SubscriberConfig a subscriber" "Template TemplateConfig a template" "Vod VodConfig a VOD location"
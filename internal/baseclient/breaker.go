@@ -0,0 +1,139 @@
+package baseclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flussonic/go-flussonic/apierror"
+	"github.com/flussonic/go-flussonic/config"
+)
+
+// breakerOutcome is the result of an attempt reported to the breaker.
+type breakerOutcome int
+
+const (
+	outcomeSuccess breakerOutcome = iota
+	outcomeFailure
+	// outcomeIgnored releases a half-open probe without changing state (e.g. canceled context)
+	outcomeIgnored
+)
+
+// breaker is a circuit breaker for a single host.
+type breaker struct {
+	mu       sync.Mutex
+	baseURL  string
+	settings *config.CircuitBreaker
+	state    config.CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+	// generation is incremented on every state change, outcomes of attempts
+	// allowed in a previous generation are ignored
+	generation uint64
+}
+
+func newBreaker(baseURL string, settings *config.CircuitBreaker) *breaker {
+	return &breaker{baseURL: baseURL, settings: settings}
+}
+
+func (b *breaker) threshold() int {
+	if b.settings.FailureThreshold > 0 {
+		return b.settings.FailureThreshold
+	}
+	return config.DefaultBreakerFailureThreshold
+}
+
+func (b *breaker) cooldown() time.Duration {
+	if b.settings.Cooldown > 0 {
+		return b.settings.Cooldown
+	}
+	return config.DefaultBreakerCooldown
+}
+
+func (b *breaker) halfOpenRequests() int {
+	if b.settings.HalfOpenRequests > 0 {
+		return b.settings.HalfOpenRequests
+	}
+	return config.DefaultBreakerHalfOpenRequests
+}
+
+// allow checks whether an attempt may be sent and returns apierror.ErrCircuitOpen if not.
+// The returned done function records the outcome of the allowed attempt.
+func (b *breaker) allow() (done func(outcome breakerOutcome), err error) {
+	b.mu.Lock()
+	from := b.state
+
+	if b.state == config.CircuitOpen && time.Since(b.openedAt) >= b.cooldown() {
+		b.setState(config.CircuitHalfOpen)
+	}
+
+	switch b.state {
+	case config.CircuitOpen:
+		err = fmt.Errorf("%w: %s", apierror.ErrCircuitOpen, b.baseURL)
+	case config.CircuitHalfOpen:
+		if b.probes >= b.halfOpenRequests() {
+			err = fmt.Errorf("%w: %s (half-open)", apierror.ErrCircuitOpen, b.baseURL)
+		} else {
+			b.probes++
+		}
+	}
+	generation := b.generation
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return func(outcome breakerOutcome) {
+		b.done(generation, outcome)
+	}, nil
+}
+
+// done records the outcome of an attempt allowed in the given generation.
+// An attempt started before the last state change, e.g. sent before the circuit
+// opened and finished while it is half-open, does not affect the current state.
+func (b *breaker) done(generation uint64, outcome breakerOutcome) {
+	b.mu.Lock()
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	from := b.state
+
+	if b.state == config.CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+
+	switch outcome {
+	case outcomeSuccess:
+		b.failures = 0
+		if b.state == config.CircuitHalfOpen {
+			b.setState(config.CircuitClosed)
+		}
+	case outcomeFailure:
+		b.failures++
+		if b.state == config.CircuitHalfOpen || b.failures >= b.threshold() {
+			b.setState(config.CircuitOpen)
+			b.openedAt = time.Now()
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// setState switches the breaker to state and starts a new generation, b.mu must be held.
+func (b *breaker) setState(state config.CircuitState) {
+	b.state = state
+	b.probes = 0
+	b.generation++
+}
+
+func (b *breaker) notify(from, to config.CircuitState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.baseURL, from, to)
+	}
+}
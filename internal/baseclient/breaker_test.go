@@ -0,0 +1,202 @@
+package baseclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flussonic/go-flussonic/apierror"
+	"github.com/flussonic/go-flussonic/config"
+	"github.com/flussonic/go-flussonic/internal/baseclient"
+)
+
+type stateChange struct {
+	baseURL  string
+	from, to config.CircuitState
+}
+
+// TestRequest_CircuitBreaker tests closed -> open -> half-open -> closed transitions
+func (s *BaseClientTestSuite) TestRequest_CircuitBreaker() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if healthy.Load() {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var changes []stateChange
+	settings := &config.CircuitBreaker{
+		FailureThreshold: 2,
+		Cooldown:         100 * time.Millisecond,
+		OnStateChange: func(baseURL string, from, to config.CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, stateChange{baseURL, from, to})
+		},
+	}
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithCircuitBreaker(settings))
+	do := func() error {
+		req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+		s.Require().NoError(err)
+		return client.Request(context.Background(), req, nil)
+	}
+
+	// Two failures open the circuit
+	s.Require().Error(do())
+	s.Require().Error(do())
+	err := do()
+	s.Require().ErrorIs(err, apierror.ErrCircuitOpen)
+	s.Require().Equal(int32(2), attempts.Load(), "open circuit must not send requests")
+
+	// After cooldown a probe is allowed and closes the circuit on success
+	time.Sleep(150 * time.Millisecond)
+	healthy.Store(true)
+	s.Require().NoError(do())
+	s.Require().NoError(do())
+	s.Require().Equal(int32(4), attempts.Load())
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Require().Equal([]stateChange{
+		{server.URL, config.CircuitClosed, config.CircuitOpen},
+		{server.URL, config.CircuitOpen, config.CircuitHalfOpen},
+		{server.URL, config.CircuitHalfOpen, config.CircuitClosed},
+	}, changes)
+}
+
+// TestRequest_CircuitBreakerHalfOpenFailure tests that failed probe opens the circuit again
+func (s *BaseClientTestSuite) TestRequest_CircuitBreakerHalfOpenFailure() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	settings := &config.CircuitBreaker{FailureThreshold: 1, Cooldown: 50 * time.Millisecond}
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithCircuitBreaker(settings))
+	do := func() error {
+		req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+		s.Require().NoError(err)
+		return client.Request(context.Background(), req, nil)
+	}
+
+	s.Require().Error(do())
+	s.Require().ErrorIs(do(), apierror.ErrCircuitOpen)
+	time.Sleep(80 * time.Millisecond)
+	err := do()
+	s.Require().Error(err)
+	s.Require().NotErrorIs(err, apierror.ErrCircuitOpen, "probe request should be sent")
+	s.Require().ErrorIs(do(), apierror.ErrCircuitOpen)
+	s.Require().Equal(int32(2), attempts.Load())
+}
+
+// TestRequest_CircuitBreakerPerClient tests that each client keeps its own breaker state
+func (s *BaseClientTestSuite) TestRequest_CircuitBreakerPerClient() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CircuitBreaker: &config.CircuitBreaker{FailureThreshold: 1, Cooldown: time.Minute}}
+	first := baseclient.New(nil, server.URL, nil, "", 0, baseclient.ConfigOptions(cfg)...)
+	second := baseclient.New(nil, server.URL, nil, "", 0, baseclient.ConfigOptions(cfg)...)
+
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().Error(first.Request(context.Background(), req, nil))
+
+	req, err = http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().ErrorIs(first.Request(context.Background(), req, nil), apierror.ErrCircuitOpen)
+
+	req, err = http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	err = second.Request(context.Background(), req, nil)
+	s.Require().Error(err)
+	s.Require().NotErrorIs(err, apierror.ErrCircuitOpen)
+	s.Require().Equal(int32(2), attempts.Load())
+}
+
+// TestRequest_CircuitBreakerStaleOutcome tests that an attempt sent before the circuit opened
+// does not close it when it finishes during half-open state
+func (s *BaseClientTestSuite) TestRequest_CircuitBreakerStaleOutcome() {
+	s.T().Parallel()
+	received := make(chan string, 4)
+	release := map[string]chan struct{}{"/slow": make(chan struct{}), "/probe": make(chan struct{})}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.URL.Path
+		if wait, ok := release[r.URL.Path]; ok {
+			<-wait
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	settings := &config.CircuitBreaker{FailureThreshold: 1, Cooldown: 50 * time.Millisecond}
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithCircuitBreaker(settings))
+	do := func(path string) error {
+		req, err := http.NewRequest("GET", server.URL+path, http.NoBody)
+		s.Require().NoError(err)
+		return client.Request(context.Background(), req, nil)
+	}
+	start := func(path string) chan error {
+		result := make(chan error, 1)
+		go func() { result <- do(path) }()
+		s.Require().Equal(path, <-received)
+		return result
+	}
+
+	// The slow attempt is sent while the circuit is closed, then a failure opens it
+	slow := start("/slow")
+	s.Require().Error(do("/fail"))
+	s.Require().Equal("/fail", <-received)
+
+	// After cooldown the probe is in flight when the slow attempt succeeds
+	time.Sleep(80 * time.Millisecond)
+	probe := start("/probe")
+	close(release["/slow"])
+	s.Require().NoError(<-slow)
+
+	// The stale success did not close the circuit, the probe slot is still taken
+	s.Require().ErrorIs(do("/fail"), apierror.ErrCircuitOpen)
+
+	close(release["/probe"])
+	s.Require().NoError(<-probe)
+	s.Require().NoError(do("/slow"), "successful probe closes the circuit")
+}
+
+// TestRequest_CircuitBreakerIgnores4xx tests that client errors do not open the circuit
+func (s *BaseClientTestSuite) TestRequest_CircuitBreakerIgnores4xx() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	settings := &config.CircuitBreaker{FailureThreshold: 1}
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithCircuitBreaker(settings))
+	for range 3 {
+		req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+		s.Require().NoError(err)
+		err = client.Request(context.Background(), req, nil)
+		s.Require().Error(err)
+		s.Require().NotErrorIs(err, apierror.ErrCircuitOpen)
+	}
+}
@@ -4,21 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/flussonic/go-flussonic/apierror"
 	"github.com/flussonic/go-flussonic/authorization"
+	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/reqctx"
 )
 
 type BaseClient interface {
 	Request(ctx context.Context, request *http.Request, result any) error
+	RequestStream(ctx context.Context, request *http.Request) (*http.Response, error)
 }
 
 // baseClient contains shared HTTP client and base URL.
@@ -28,44 +34,191 @@ type baseClient struct {
 	Authorization authorization.AuthKey
 	Originator    string
 	Retry         uint
+	RetryPolicy   config.RetryPolicy
+	Limiter       config.Limiter
+	Breaker       *breaker
+	Logger        *slog.Logger
+	LogBodies     bool
+	Redaction     *config.Redaction
+	Product       string
+	Metrics       *metrics
 }
 
 const TraceIDHeader = "X-Trace-Id"
 
+// Option configures optional behavior of the base client.
+type Option func(c *baseClient)
+
+// WithRetryPolicy sets the policy deciding which attempts are retried.
+func WithRetryPolicy(policy config.RetryPolicy) Option {
+	return func(c *baseClient) {
+		if policy != nil {
+			c.RetryPolicy = policy
+		}
+	}
+}
+
+// WithLimiter sets the limiter every attempt has to pass before being sent.
+func WithLimiter(limiter config.Limiter) Option {
+	return func(c *baseClient) {
+		c.Limiter = limiter
+	}
+}
+
+// WithCircuitBreaker enables the circuit breaker of the client with the given settings.
+func WithCircuitBreaker(settings *config.CircuitBreaker) Option {
+	return func(c *baseClient) {
+		if settings != nil {
+			c.Breaker = newBreaker(c.BaseURL, settings)
+		}
+	}
+}
+
+// ConfigOptions returns options derived from the client configuration.
+// If cfg.Limiter is not set but RateLimit or MaxInFlight are, a limiter is created
+// and stored in cfg, so clients created from the same config share it.
+func ConfigOptions(cfg *config.Config) []Option {
+	if cfg.Limiter == nil && (cfg.RateLimit > 0 || cfg.MaxInFlight > 0) {
+		cfg.Limiter = config.NewLimiter(cfg.RateLimit, cfg.RateBurst, cfg.MaxInFlight)
+	}
+
+	return []Option{
+		WithRetryPolicy(cfg.RetryPolicy),
+		WithLimiter(cfg.Limiter),
+		WithCircuitBreaker(cfg.CircuitBreaker),
+		WithLogger(cfg.Logger, cfg.LogBodies, cfg.Redaction),
+		WithMeterProvider(cfg.MeterProvider),
+	}
+}
+
 // New creates a new instance of base client.
-func New(httpClient *http.Client, baseURL string, authKey authorization.AuthKey, originator string, retry uint) BaseClient {
+func New(
+	httpClient *http.Client,
+	baseURL string,
+	authKey authorization.AuthKey,
+	originator string,
+	retry uint,
+	opts ...Option,
+) BaseClient {
 	if httpClient == nil {
 		httpClient = &http.Client{} // Use default client if not provided
 	}
 
 	c := *httpClient
+	c.CheckRedirect = checkRedirect(c.CheckRedirect)
 
 	c.Transport = otelhttp.NewTransport(
 		c.Transport,
 		otelhttp.WithPropagators(propagation.TraceContext{}),
 	)
 
-	return &baseClient{
+	client := &baseClient{
 		HTTPClient:    &c,
 		BaseURL:       baseURL,
 		Authorization: authKey,
 		Originator:    originator,
 		Retry:         retry,
+		RetryPolicy:   config.DefaultRetryPolicy(),
+		Metrics:       newMetrics(otel.GetMeterProvider()),
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
+
+	return client
 }
 
 // Request performs HTTP request and decodes response into result (for success)
-// or into apierror.ErrorResponse (for errors).
-// Method supports retry logic and uses timeout from httpClient.
+// or returns *apierror.HTTPError wrapping apierror.ErrorResponse (for errors).
+// Method supports retry logic driven by RetryPolicy and uses timeout from httpClient.
+// Waiting between attempts is interrupted when ctx is done.
 // Status codes < 400 are considered successful, >= 400 - errors.
 func (c *baseClient) Request(ctx context.Context, request *http.Request, result any) error {
+	response, responseBody, err := c.do(ctx, request, false)
+	if err != nil {
+		return err
+	}
+
+	// Successful response - parse into result, 204 No Content leaves result untouched
+	if result != nil && response.StatusCode != http.StatusNoContent {
+		if err := json.Unmarshal(responseBody, result); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		c.recordItems(ctx, result)
+	}
+	return nil
+}
+
+// RequestStream performs HTTP request like Request but returns the successful response
+// with unread body, so non-JSON and large responses can be streamed.
+// The caller must close the response body.
+func (c *baseClient) RequestStream(ctx context.Context, request *http.Request) (*http.Response, error) {
+	response, _, err := c.do(ctx, request, true)
+	return response, err
+}
+
+// do performs the request with retries and records call metrics. On success the response body
+// is read and returned unless stream is set, in which case the body is left for the caller to read and close.
+// Per-call options attached to ctx with reqctx.With are applied.
+func (c *baseClient) do(ctx context.Context, request *http.Request, stream bool) (*http.Response, []byte, error) {
+	options := reqctx.FromContext(ctx)
+	cancel := context.CancelFunc(func() {})
+	if options.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+	}
+
+	started := time.Now()
+	attempts := 0
+	response, body, err := c.send(ctx, request, stream, options, &attempts)
+	c.recordCall(ctx, started, attempts, response, err)
+
+	if stream && err == nil {
+		// The call timeout applies until the streamed body is closed
+		response.Body = &releaseOnClose{ReadCloser: response.Body, release: cancel}
+	} else {
+		cancel()
+	}
+	return response, body, err
+}
+
+// send performs the request with retries, attempts is set to the number of sent attempts.
+func (c *baseClient) send(
+	ctx context.Context,
+	request *http.Request,
+	stream bool,
+	options reqctx.Options,
+	attempts *int,
+) (*http.Response, []byte, error) {
 	if request == nil {
-		return fmt.Errorf("request is nil")
+		return nil, nil, fmt.Errorf("request is nil")
 	}
 
-	// Set authorization header if Auth is set
-	if c.Authorization != nil {
-		request.Header.Set("Authorization", c.Authorization.ToHeader())
+	// Extra headers do not replace headers of the request, e.g. Content-Type of an upload,
+	// and are replaced by headers set by the client below
+	for name, values := range options.Headers {
+		name = http.CanonicalHeaderKey(name)
+		if _, ok := request.Header[name]; !ok {
+			request.Header[name] = values
+		}
+	}
+
+	if options.IdempotencyKey != "" {
+		request.Header.Set(reqctx.IdempotencyKeyHeader, options.IdempotencyKey)
+	}
+
+	// Set authorization header if Auth is set, refreshable credentials are obtained on first use
+	refresher, _ := c.Authorization.(authorization.RefreshableAuthKey)
+	var authHeader string
+	if refresher != nil {
+		var err error
+		if authHeader, err = refresher.HeaderContext(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to authorize request: %w", err)
+		}
+	} else if c.Authorization != nil {
+		authHeader = c.Authorization.ToHeader()
+	}
+	if authHeader != "" {
+		request.Header.Set("Authorization", authHeader)
 	}
 
 	if c.Originator != "" {
@@ -76,13 +229,14 @@ func (c *baseClient) Request(ctx context.Context, request *http.Request, result
 		request.Header.Set(TraceIDHeader, traceID)
 	}
 
-	// Save request body for retry attempts
+	// Save request body for retry attempts unless it can be replayed with GetBody,
+	// so streaming uploads are not buffered in memory
 	var bodyBytes []byte
-	if request.Body != nil {
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
 		var err error
 		bodyBytes, err = io.ReadAll(request.Body)
 		if err != nil {
-			return fmt.Errorf("failed to read request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read request body: %w", err)
 		}
 		_ = request.Body.Close()
 	}
@@ -93,83 +247,228 @@ func (c *baseClient) Request(ctx context.Context, request *http.Request, result
 	// Calculate total attempts: 1 initial + retry attempts
 	// retry = 0 means no retries (1 total attempt)
 	// retry = 1 means 1 retry (2 total attempts)
-	totalAttempts := 1 + c.Retry
-	timeout := time.Second
+	retry := c.Retry
+	if options.Retry != nil {
+		retry = *options.Retry
+	}
+	policy := c.RetryPolicy
+	if options.RetryPolicy != nil {
+		policy = options.RetryPolicy
+	}
+	totalAttempts := int(1 + retry)
 	var lastErr error
 	var lastStatusCode int
+	attempt := 0
+	reauthenticated, resigned := false, false
+
+	for attempt < totalAttempts {
+		attempt++
 
-	for attempt := range totalAttempts {
 		// Restore request body for each attempt
 		if len(bodyBytes) > 0 {
 			request.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		} else if attempt > 1 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				// Body can not be sent again, report the previous failure
+				attempt--
+				break
+			}
+			request.Body = body
 		}
 
-		// Execute request
-		response, err := c.HTTPClient.Do(request)
+		retryAttempt := config.RetryAttempt{Method: request.Method, Attempt: attempt, IdempotencyKey: options.IdempotencyKey}
+
+		done, err := c.allow()
 		if err != nil {
-			lastErr = fmt.Errorf("http request failed (attempt %d/%d): %w", attempt+1, totalAttempts, err)
-			lastStatusCode = http.StatusServiceUnavailable
+			return nil, nil, fmt.Errorf("request failed after %d attempts: %w", attempt-1, errors.Join(err, lastErr))
+		}
 
-			// If this is not the last attempt, wait before next one
-			if attempt < totalAttempts-1 {
-				time.Sleep(timeout)
-				timeout += time.Second
-			}
-			continue
+		release, err := c.acquire(ctx)
+		if err != nil {
+			done(outcomeIgnored)
+			return nil, nil, fmt.Errorf("request throttled after %d attempts: %w", attempt-1, errors.Join(err, lastErr))
 		}
 
-		// Read response body
-		responseBody, err := io.ReadAll(response.Body)
-		_ = response.Body.Close() // Close immediately after reading
+		// Execute request
+		*attempts = attempt
+		logBodies := c.LogBodies && !options.NoLogBody
+		capture := c.captureBody(request, logBodies)
+		started := time.Now()
+		response, err := c.HTTPClient.Do(request)
+		entry := attemptLog{request: request, attempt: attempt, logBodies: logBodies, requestBody: capture}
+		clockAdjusted := err == nil && c.adjustClock(response)
 		if err != nil {
-			lastErr = fmt.Errorf("failed to read response body (attempt %d/%d): %w", attempt+1, totalAttempts, err)
+			lastErr = fmt.Errorf("http request failed (attempt %d/%d): %w", attempt, totalAttempts, err)
+			lastStatusCode = http.StatusServiceUnavailable
+			retryAttempt.Err = err
+			entry.err = err
+		} else if stream && response.StatusCode < 400 {
+			// Successful response - hand the body to the caller, limiter is released on close
+			done(outcomeSuccess)
+			entry.latency, entry.statusCode = time.Since(started), response.StatusCode
+			c.logAttempt(ctx, entry)
+			response.Body = &releaseOnClose{ReadCloser: response.Body, release: release}
+			return response, nil, nil
+		} else {
+			// Read response body
+			responseBody, readErr := io.ReadAll(response.Body)
+			_ = response.Body.Close() // Close immediately after reading
 			lastStatusCode = response.StatusCode
+			retryAttempt.StatusCode = response.StatusCode
+			entry.statusCode, entry.responseBody, entry.err = response.StatusCode, responseBody, readErr
 
-			if attempt < totalAttempts-1 {
-				time.Sleep(timeout)
-				timeout += time.Second
+			switch {
+			case readErr != nil:
+				lastErr = fmt.Errorf("failed to read response body (attempt %d/%d): %w", attempt, totalAttempts, readErr)
+				retryAttempt.Err = readErr
+			case response.StatusCode < 400:
+				release()
+				done(outcomeSuccess)
+				entry.latency = time.Since(started)
+				c.logAttempt(ctx, entry)
+				return response, responseBody, nil
+			default:
+				httpErr := parseErrorResponse(request, response.StatusCode, responseBody)
+				httpErr.Attempts = attempt
+				lastErr = httpErr
+				retryAttempt.RetryAfter = config.ParseRetryAfter(response.Header.Get("Retry-After"), time.Now())
 			}
-			continue
 		}
+		entry.latency = time.Since(started)
+		c.logAttempt(ctx, entry)
+		release()
+		done(attemptOutcome(ctx, retryAttempt))
 
-		lastStatusCode = response.StatusCode
-
-		// Check response code: < 400 - success, >= 400 - error
-		if response.StatusCode < 400 {
-			// Successful response - parse into result
-			if result != nil {
-				if err := json.Unmarshal(responseBody, result); err != nil {
-					return fmt.Errorf("failed to unmarshal response: %w", err)
-				}
+		if retryAttempt.StatusCode == http.StatusUnauthorized && refresher != nil && !reauthenticated {
+			// Credentials were rejected, replay the request once with new ones
+			// without spending a retry
+			reauthenticated = true
+			refreshed, err := refresher.Refresh(ctx, authHeader)
+			if err != nil {
+				lastErr = errors.Join(lastErr, fmt.Errorf("failed to refresh authorization: %w", err))
+				break
 			}
-			return nil
-		}
-
-		// Status >= 400 - error, try to parse as ErrorResponse
-		var apiErr apierror.ErrorResponse
-		if err := json.Unmarshal(responseBody, &apiErr); err != nil || len(apiErr.Errors) == 0 {
-			// Failed to parse as ErrorResponse - return as string
-			lastErr = fmt.Errorf("API error (status %d): %s", response.StatusCode, string(responseBody))
-		} else {
-			// Successfully parsed ErrorResponse
-			lastErr = &apiErr
+			authHeader = refreshed
+			request.Header.Set("Authorization", authHeader)
+			totalAttempts++
+			continue
 		}
 
-		// If this is not the last attempt and error code allows retry
-		// (e.g., 5xx errors should be retried, 4xx - not)
-		if attempt < totalAttempts-1 && response.StatusCode >= 500 {
-			time.Sleep(timeout)
-			timeout += time.Second
+		if retryAttempt.StatusCode == http.StatusForbidden && clockAdjusted && !resigned {
+			// The request was signed with a skewed clock, replay it once signed with the server time
+			resigned = true
+			authHeader = c.Authorization.ToHeader()
+			request.Header.Set("Authorization", authHeader)
+			totalAttempts++
 			continue
 		}
 
-		// For 4xx errors don't retry
-		break
+		if attempt >= totalAttempts {
+			break
+		}
+		shouldRetry, delay := policy.ShouldRetry(retryAttempt)
+		if !shouldRetry {
+			break
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, nil, fmt.Errorf("request canceled after %d attempts (last status: %d): %w", attempt, lastStatusCode, errors.Join(err, lastErr))
+		}
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("request failed after %d attempts (last status: %d): %w", totalAttempts, lastStatusCode, lastErr)
+		return nil, nil, fmt.Errorf("request failed after %d attempts (last status: %d): %w", attempt, lastStatusCode, lastErr)
+	}
+
+	return nil, nil, fmt.Errorf("request failed after %d attempts with status %d", attempt, lastStatusCode)
+}
+
+// adjustClock passes the server time from the Date header of response to the authorization
+// signing timestamps and reports whether the signature changed.
+func (c *baseClient) adjustClock(response *http.Response) bool {
+	adjuster, ok := c.Authorization.(authorization.ClockAdjuster)
+	if !ok {
+		return false
+	}
+	serverTime, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+	return adjuster.AdjustClock(serverTime, time.Now())
+}
+
+// releaseOnClose calls release once when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close closes the body and releases the limiter slot.
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// allow checks the circuit breaker if it is configured.
+// The returned done function reports the outcome of the attempt.
+func (c *baseClient) allow() (done func(outcome breakerOutcome), err error) {
+	if c.Breaker == nil {
+		return func(breakerOutcome) {}, nil
 	}
+	return c.Breaker.allow()
+}
 
-	return fmt.Errorf("request failed after %d attempts with status %d", totalAttempts, lastStatusCode)
+// attemptOutcome classifies a failed attempt for the circuit breaker.
+func attemptOutcome(ctx context.Context, attempt config.RetryAttempt) breakerOutcome {
+	switch {
+	case attempt.Err != nil && ctx.Err() != nil:
+		return outcomeIgnored
+	case attempt.Err != nil, attempt.StatusCode >= 500:
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
+
+// acquire waits for the limiter if it is configured.
+func (c *baseClient) acquire(ctx context.Context) (release func(), err error) {
+	if c.Limiter == nil {
+		return func() {}, nil
+	}
+	return c.Limiter.Acquire(ctx)
+}
+
+// parseErrorResponse converts error response into apierror.HTTPError,
+// parsing the body as apierror.ErrorResponse if possible.
+func parseErrorResponse(request *http.Request, statusCode int, body []byte) *apierror.HTTPError {
+	httpErr := &apierror.HTTPError{
+		StatusCode: statusCode,
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		TraceID:    request.Header.Get(TraceIDHeader),
+		Body:       body,
+	}
+	var apiErr apierror.ErrorResponse
+	if err := json.Unmarshal(body, &apiErr); err == nil && len(apiErr.Errors) > 0 {
+		httpErr.Response = &apiErr
+	}
+	return httpErr
+}
+
+// sleep waits for the given duration or until context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
@@ -1,13 +1,17 @@
 package baseclient_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -16,6 +20,8 @@ import (
 	_ "golang.org/x/sys/unix"
 
 	"github.com/flussonic/go-flussonic/apierror"
+	"github.com/flussonic/go-flussonic/authorization"
+	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
 	"github.com/flussonic/go-flussonic/reqctx"
 )
@@ -168,6 +174,81 @@ func (s *BaseClientTestSuite) TestRequest_ApiError() {
 	s.Require().Contains(err.Error(), "invalid request")
 }
 
+// TestRequest_HTTPError tests that API errors are returned as typed HTTPError
+func (s *BaseClientTestSuite) TestRequest_HTTPError() {
+	s.T().Parallel()
+	message := "stream name is invalid"
+	code := "validation_error"
+	pointer := "/name"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(apierror.ErrorResponse{
+			Errors: []apierror.Error{
+				{Title: &message, Code: &code, Source: &apierror.ErrorSource{Pointer: &pointer}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+	ctx := reqctx.WithTraceID(context.Background(), "trace-422")
+	req, err := http.NewRequest("PUT", server.URL+"/streams/test", strings.NewReader(`{}`))
+	s.Require().NoError(err)
+
+	err = client.Request(ctx, req, nil)
+
+	httpErr, ok := apierror.AsHTTPError(err)
+	s.Require().True(ok)
+	s.Require().Equal(http.StatusUnprocessableEntity, httpErr.StatusCode)
+	s.Require().Equal("PUT", httpErr.Method)
+	s.Require().Equal(server.URL+"/streams/test", httpErr.URL)
+	s.Require().Equal("trace-422", httpErr.TraceID)
+	s.Require().Equal(1, httpErr.Attempts)
+	s.Require().Equal([]string{"/name"}, httpErr.Pointers())
+	s.Require().True(apierror.IsValidation(err))
+	s.Require().False(apierror.IsNotFound(err))
+
+	var apiErr *apierror.ErrorResponse
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal("validation_error", *apiErr.Errors[0].Code)
+}
+
+// TestRequest_HTTPErrorStatusMatchers tests status matchers for non-JSON error bodies
+func (s *BaseClientTestSuite) TestRequest_HTTPErrorStatusMatchers() {
+	s.T().Parallel()
+	tests := []struct {
+		status  int
+		matcher func(error) bool
+	}{
+		{http.StatusNotFound, apierror.IsNotFound},
+		{http.StatusConflict, apierror.IsConflict},
+		{http.StatusUnauthorized, apierror.IsUnauthorized},
+		{http.StatusForbidden, apierror.IsForbidden},
+		{http.StatusBadRequest, apierror.IsValidation},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte("<html>error</html>"))
+		}))
+
+		client := baseclient.New(nil, server.URL, nil, "", 0)
+		req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+		s.Require().NoError(err)
+		err = client.Request(context.Background(), req, nil)
+		server.Close()
+
+		s.Require().True(tt.matcher(err), "status %d", tt.status)
+		s.Require().Equal(tt.status, apierror.StatusCode(err))
+		httpErr, ok := apierror.AsHTTPError(err)
+		s.Require().True(ok)
+		s.Require().Nil(httpErr.Response)
+		s.Require().Equal("<html>error</html>", string(httpErr.Body))
+		var apiErr *apierror.ErrorResponse
+		s.Require().NotErrorAs(err, &apiErr)
+	}
+}
+
 // TestRequest_ApiErrorNonJSON tests API error with non-JSON response
 func (s *BaseClientTestSuite) TestRequest_ApiErrorNonJSON() {
 	s.T().Parallel()
@@ -429,7 +510,7 @@ func (s *BaseClientTestSuite) TestRequest_RetryWithBody() {
 	ctx := context.Background()
 
 	bodyStr := `{"data":"test"}`
-	req, err := http.NewRequest("POST", server.URL+"/test", strings.NewReader(bodyStr))
+	req, err := http.NewRequest("PUT", server.URL+"/test", strings.NewReader(bodyStr))
 	s.Require().NoError(err)
 
 	var result map[string]string
@@ -532,6 +613,601 @@ func (s *BaseClientTestSuite) TestRequest_OneRetry() {
 	s.Require().Contains(err.Error(), "request failed after 2 attempts")
 }
 
+// TestRequest_PostNotRetried tests that non-idempotent POST is not retried by default policy
+func (s *BaseClientTestSuite) TestRequest_PostNotRetried() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 3)
+	req, err := http.NewRequest("POST", server.URL+"/test", strings.NewReader(`{"data":"test"}`))
+	s.Require().NoError(err)
+
+	err = client.Request(context.Background(), req, nil)
+
+	s.Require().Error(err)
+	s.Require().Equal(int32(1), attempts.Load(), "POST should not be retried")
+	s.Require().Contains(err.Error(), "request failed after 1 attempts")
+}
+
+// TestRequest_PostRetriedWhenAllowed tests that POST is retried when policy allows it
+func (s *BaseClientTestSuite) TestRequest_PostRetriedWhenAllowed() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &config.ExponentialBackoff{InitialDelay: time.Millisecond, RetryNonIdempotent: true}
+	client := baseclient.New(nil, server.URL, nil, "", 2, baseclient.WithRetryPolicy(policy))
+	req, err := http.NewRequest("POST", server.URL+"/test", strings.NewReader(`{"data":"test"}`))
+	s.Require().NoError(err)
+
+	err = client.Request(context.Background(), req, nil)
+
+	s.Require().Error(err)
+	s.Require().Equal(int32(3), attempts.Load())
+}
+
+// TestRequest_RetryAfter tests that Retry-After header is honored on 429
+func (s *BaseClientTestSuite) TestRequest_RetryAfter() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	policy := &config.ExponentialBackoff{InitialDelay: time.Millisecond}
+	client := baseclient.New(nil, server.URL, nil, "", 1, baseclient.WithRetryPolicy(policy))
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	started := time.Now()
+	var result map[string]string
+	err = client.Request(context.Background(), req, &result)
+
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), attempts.Load())
+	s.Require().GreaterOrEqual(time.Since(started), time.Second, "should wait for Retry-After")
+}
+
+// TestRequest_ContextCanceledDuringBackoff tests that waiting between attempts respects context
+func (s *BaseClientTestSuite) TestRequest_ContextCanceledDuringBackoff() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	policy := &config.ExponentialBackoff{InitialDelay: time.Minute}
+	client := baseclient.New(nil, server.URL, nil, "", 3, baseclient.WithRetryPolicy(policy))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	started := time.Now()
+	err = client.Request(ctx, req, nil)
+
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+	s.Require().Less(time.Since(started), 10*time.Second)
+	s.Require().Equal(int32(1), attempts.Load())
+}
+
+// TestRequest_CustomRetryPolicy tests that custom policy receives attempt details
+func (s *BaseClientTestSuite) TestRequest_CustomRetryPolicy() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	policy := &recordingPolicy{}
+	client := baseclient.New(nil, server.URL, nil, "", 2, baseclient.WithRetryPolicy(policy))
+	req, err := http.NewRequest("DELETE", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	err = client.Request(context.Background(), req, nil)
+
+	s.Require().Error(err)
+	s.Require().Equal(int32(3), attempts.Load(), "custom policy retries 409")
+	s.Require().Len(policy.attempts, 2)
+	s.Require().Equal("DELETE", policy.attempts[0].Method)
+	s.Require().Equal(http.StatusConflict, policy.attempts[0].StatusCode)
+	s.Require().Equal(1, policy.attempts[0].Attempt)
+	s.Require().Equal(2, policy.attempts[1].Attempt)
+}
+
+// TestRequest_LimiterShared tests that a limiter shared between clients caps concurrency
+func (s *BaseClientTestSuite) TestRequest_LimiterShared() {
+	s.T().Parallel()
+	var current, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{MaxInFlight: 1}
+	first := baseclient.New(nil, server.URL, nil, "", 0, baseclient.ConfigOptions(cfg)...)
+	second := baseclient.New(nil, server.URL, nil, "", 0, baseclient.ConfigOptions(cfg)...)
+	s.Require().NotNil(cfg.Limiter)
+
+	var wg sync.WaitGroup
+	for i := range 6 {
+		client := first
+		if i%2 == 1 {
+			client = second
+		}
+		wg.Go(func() {
+			req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+			s.Require().NoError(err)
+			s.Require().NoError(client.Request(context.Background(), req, nil))
+		})
+	}
+	wg.Wait()
+
+	s.Require().Equal(int32(1), peak.Load())
+}
+
+// TestRequest_LimiterDeadline tests that waiting for limiter respects context deadline
+func (s *BaseClientTestSuite) TestRequest_LimiterDeadline() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithLimiter(config.NewLimiter(0.01, 1, 0)))
+
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err = http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	err = client.Request(ctx, req, nil)
+
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+	s.Require().Equal(int32(1), attempts.Load())
+}
+
+// TestRequestStream_ReturnsBody verifies that non-JSON body is returned unread and limiter is held until Close
+func (s *BaseClientTestSuite) TestRequestStream_ReturnsBody() {
+	s.T().Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("name,alive\nfoo,true\n"))
+	}))
+	defer server.Close()
+
+	limiter := config.NewLimiter(0, 0, 1)
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithLimiter(limiter))
+
+	req, err := http.NewRequest("GET", server.URL+"/streams?format=csv", http.NoBody)
+	s.Require().NoError(err)
+	response, err := client.RequestStream(context.Background(), req)
+	s.Require().NoError(err)
+
+	body, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+	s.Require().Equal("name,alive\nfoo,true\n", string(body))
+
+	// The only in-flight slot is taken until the body is closed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+
+	s.Require().NoError(response.Body.Close())
+	s.Require().NoError(response.Body.Close())
+	release, err := limiter.Acquire(context.Background())
+	s.Require().NoError(err)
+	release()
+}
+
+// TestRequestStream_Error verifies that error responses are returned as HTTPError
+func (s *BaseClientTestSuite) TestRequestStream_Error() {
+	s.T().Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"404","title":"not found"}]}`))
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+
+	req, err := http.NewRequest("GET", server.URL+"/config", http.NoBody)
+	s.Require().NoError(err)
+	response, err := client.RequestStream(context.Background(), req)
+
+	s.Require().Nil(response)
+	s.Require().True(apierror.IsNotFound(err))
+}
+
+// TestRequest_EmptyBodyWithResult tests that 204 response leaves result untouched
+func (s *BaseClientTestSuite) TestRequest_EmptyBodyWithResult() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	var result any
+	s.Require().NoError(client.Request(context.Background(), req, &result))
+	s.Require().Nil(result)
+}
+
+// TestRequest_EmptyBodyWithStatusOK tests that empty 200 response is not a valid result
+func (s *BaseClientTestSuite) TestRequest_EmptyBodyWithStatusOK() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	var result map[string]any
+	err = client.Request(context.Background(), req, &result)
+	s.Require().ErrorContains(err, "failed to unmarshal response")
+}
+
+// TestRequestStream_WithoutRedirects tests that redirects are returned instead of followed
+func (s *BaseClientTestSuite) TestRequestStream_WithoutRedirects() {
+	s.T().Parallel()
+	var followed atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/target" {
+			followed.Add(1)
+			return
+		}
+		http.Redirect(w, r, "/target", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+
+	req, err := http.NewRequest("GET", server.URL+"/lb", http.NoBody)
+	s.Require().NoError(err)
+	response, err := client.RequestStream(baseclient.WithoutRedirects(context.Background()), req)
+	s.Require().NoError(err)
+	s.Require().NoError(response.Body.Close())
+	s.Require().Equal(http.StatusFound, response.StatusCode)
+	s.Require().Equal("/target", response.Header.Get("Location"))
+	s.Require().Equal(int32(0), followed.Load())
+
+	// Redirects of other requests are still followed
+	req, err = http.NewRequest("GET", server.URL+"/lb", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int32(1), followed.Load())
+}
+
+// TestRequest_Logging tests that attempts are logged with masked credentials
+func (s *BaseClientTestSuite) TestRequest_Logging() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"cam1","cluster_key":"secret-key"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := baseclient.New(nil, server.URL, authorization.BasicAuth("admin", "pass"), "", 1,
+		baseclient.WithRetryPolicy(&recordingPolicy{}),
+		baseclient.WithLogger(logger, true, nil))
+
+	ctx := reqctx.WithTraceID(context.Background(), "trace-1")
+	req, err := http.NewRequest("PUT", server.URL+"/streamer/api/v3/streams/cam1?token=abc", strings.NewReader(`{"name":"cam1","password":"p"}`))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(ctx, req, nil))
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(output.String()), "\n") {
+		var record map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	s.Require().Len(records, 2)
+
+	s.Require().Equal("WARN", records[0]["level"])
+	s.Require().Equal(float64(http.StatusServiceUnavailable), records[0]["status"])
+	s.Require().Equal("DEBUG", records[1]["level"])
+	s.Require().Equal(float64(2), records[1]["attempt"])
+	for _, record := range records {
+		s.Require().Equal("PUT", record["method"])
+		s.Require().Equal("/streamer/api/v3/streams/cam1", record["path"])
+		s.Require().Equal("trace-1", record["trace_id"])
+		s.Require().Contains(record, "latency")
+		s.Require().Equal("Basic ***", record["request_headers"].(map[string]any)["Authorization"])
+		s.Require().JSONEq(`{"name":"cam1","password":"***"}`, record["request_body"].(string))
+	}
+	s.Require().JSONEq(`{"name":"cam1","cluster_key":"***"}`, records[1]["response_body"].(string))
+	s.Require().NotContains(output.String(), "secret-key")
+	s.Require().NotContains(output.String(), "token=abc")
+}
+
+// TestRequest_PerCallOptions tests headers, idempotency key and retry override from reqctx
+func (s *BaseClientTestSuite) TestRequest_PerCallOptions() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	var mu sync.Mutex
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, authorization.BearerAuth("token"), "", 0)
+
+	ctx := reqctx.With(context.Background(),
+		reqctx.Header("X-Custom", "value"),
+		reqctx.Header("Authorization", "Bearer other"),
+		reqctx.IdempotencyKey("key-1"),
+		reqctx.Retry(1),
+		reqctx.RetryPolicy(&config.ExponentialBackoff{InitialDelay: time.Millisecond}))
+	req, err := http.NewRequest("POST", server.URL+"/test", strings.NewReader(`{}`))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(ctx, req, nil))
+
+	// POST is retried because of the idempotency key
+	s.Require().Len(headers, 2)
+	for _, header := range headers {
+		s.Require().Equal("value", header.Get("X-Custom"))
+		s.Require().Equal("key-1", header.Get(reqctx.IdempotencyKeyHeader))
+		s.Require().Equal("Bearer token", header.Get("Authorization"))
+	}
+}
+
+// TestRequest_PerCallHeadersKeepRequestHeaders tests that extra headers do not replace Content-Type of the request
+func (s *BaseClientTestSuite) TestRequest_PerCallHeadersKeepRequestHeaders() {
+	s.T().Parallel()
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+
+	ctx := reqctx.With(context.Background(),
+		reqctx.Header("Content-Type", "text/plain"),
+		reqctx.Header("x-custom", "value"))
+	req, err := baseclient.NewUploadRequest(ctx, http.MethodPut, server.URL+"/test", "application/xml", strings.NewReader("<tv/>"))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(ctx, req, nil))
+
+	s.Require().Equal([]string{"application/xml"}, header.Values("Content-Type"))
+	s.Require().Equal("value", header.Get("X-Custom"))
+}
+
+// TestRequest_PerCallTimeout tests that reqctx timeout limits the call
+func (s *BaseClientTestSuite) TestRequest_PerCallTimeout() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+
+	ctx := reqctx.With(context.Background(), reqctx.Timeout(50*time.Millisecond))
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+
+	started := time.Now()
+	err = client.Request(ctx, req, nil)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+	s.Require().Less(time.Since(started), 500*time.Millisecond)
+}
+
+// TestRequest_NoLogBody tests that bodies are not logged when disabled per call
+func (s *BaseClientTestSuite) TestRequest_NoLogBody() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"private":"response"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := baseclient.New(nil, server.URL, nil, "", 0, baseclient.WithLogger(logger, true, nil))
+
+	ctx := reqctx.With(context.Background(), reqctx.NoLogBody())
+	req, err := http.NewRequest("PUT", server.URL+"/test", strings.NewReader(`{"private":"request"}`))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(ctx, req, nil))
+
+	s.Require().Contains(output.String(), `"path":"/test"`)
+	s.Require().NotContains(output.String(), "private")
+}
+
+// TestRequest_Reauthenticates tests that a request rejected with 401 is replayed once with a refreshed token
+func (s *BaseClientTestSuite) TestRequest_Reauthenticates() {
+	s.T().Parallel()
+	var logins atomic.Int32
+	auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+		return fmt.Sprintf("token-%d", logins.Add(1)), nil
+	})
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		s.Equal(`{"name":"test"}`, string(body))
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"test"}`))
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, auth, "", 0)
+
+	req, err := http.NewRequest("POST", server.URL+"/test", strings.NewReader(`{"name":"test"}`))
+	s.Require().NoError(err)
+	var result map[string]any
+	s.Require().NoError(client.Request(context.Background(), req, &result))
+	s.Require().Equal("test", result["name"])
+	s.Require().Equal(int32(2), logins.Load())
+	s.Require().Equal(int32(2), requests.Load())
+
+	// Token is cached
+	req, err = http.NewRequest("POST", server.URL+"/test", strings.NewReader(`{"name":"test"}`))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int32(2), logins.Load())
+	s.Require().Equal(int32(3), requests.Load())
+}
+
+// TestRequest_ReauthenticatesOnce tests that a request is not replayed again if the refreshed token is rejected
+func (s *BaseClientTestSuite) TestRequest_ReauthenticatesOnce() {
+	s.T().Parallel()
+	var logins atomic.Int32
+	auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+		return fmt.Sprintf("token-%d", logins.Add(1)), nil
+	})
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, auth, "", 0)
+
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	err = client.Request(context.Background(), req, nil)
+	httpErr, ok := apierror.AsHTTPError(err)
+	s.Require().True(ok)
+	s.Require().Equal(http.StatusUnauthorized, httpErr.StatusCode)
+	s.Require().Equal(int32(2), logins.Load())
+	s.Require().Equal(int32(2), requests.Load())
+}
+
+// TestRequest_AuthorizationFailed tests that the request is not sent if the token can not be obtained
+func (s *BaseClientTestSuite) TestRequest_AuthorizationFailed() {
+	s.T().Parallel()
+	loginErr := fmt.Errorf("invalid credentials")
+	auth := authorization.Refreshable(func(ctx context.Context) (string, error) {
+		return "", loginErr
+	})
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, auth, "", 0)
+
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().ErrorIs(client.Request(context.Background(), req, nil), loginErr)
+	s.Require().Zero(requests.Load())
+}
+
+// TestRequest_ClusterKeyClockSkew tests that the cluster key is signed with the server time from the Date header
+func (s *BaseClientTestSuite) TestRequest_ClusterKeyClockSkew() {
+	s.T().Parallel()
+	serverOffset := -time.Hour
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		serverTime := time.Now().Add(serverOffset)
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+		timestamp, _, _ := strings.Cut(strings.TrimPrefix(r.Header.Get("Authorization"), "Cluster "), ":")
+		unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || time.Unix(unixTime, 0).Sub(serverTime).Abs() > time.Minute {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := baseclient.New(nil, server.URL, authorization.ClusterKey("key"), "", 0)
+
+	// First request is rejected and replayed after learning the server time
+	req, err := http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int32(2), requests.Load())
+
+	req, err = http.NewRequest("GET", server.URL+"/test", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int32(3), requests.Load())
+}
+
+// recordingPolicy retries everything without delay and records attempts
+type recordingPolicy struct {
+	attempts []config.RetryAttempt
+}
+
+func (p *recordingPolicy) ShouldRetry(attempt config.RetryAttempt) (bool, time.Duration) {
+	p.attempts = append(p.attempts, attempt)
+	return true, 0
+}
+
 // errorReader is a helper type that always returns an error when Read is called
 type errorReader struct {
 	err error
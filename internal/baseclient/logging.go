@@ -0,0 +1,125 @@
+package baseclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/flussonic/go-flussonic/config"
+)
+
+// WithLogger enables logging of every request attempt. Bodies and headers are logged
+// only if logBodies is set, sensitive data is masked according to redaction.
+func WithLogger(logger *slog.Logger, logBodies bool, redaction *config.Redaction) Option {
+	return func(c *baseClient) {
+		c.Logger = logger
+		c.LogBodies = logBodies
+		c.Redaction = redaction
+	}
+}
+
+// maxCapturedBody is the maximum size of a request body captured for logging.
+const maxCapturedBody = 64 << 10
+
+// attemptLog describes a finished request attempt.
+type attemptLog struct {
+	request      *http.Request
+	attempt      int
+	logBodies    bool
+	latency      time.Duration
+	statusCode   int
+	requestBody  *bodyCapture
+	responseBody []byte
+	err          error
+}
+
+// logAttempt writes a record about the attempt: failed attempts at warning level, others at debug level.
+func (c *baseClient) logAttempt(ctx context.Context, entry attemptLog) {
+	if c.Logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	if entry.err != nil || entry.statusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	if !c.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", entry.request.Method),
+		slog.String("path", entry.request.URL.Path),
+		slog.Int("status", entry.statusCode),
+		slog.Duration("latency", entry.latency),
+		slog.Int("attempt", entry.attempt),
+	}
+	if traceID := entry.request.Header.Get(TraceIDHeader); traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+	if entry.err != nil {
+		attrs = append(attrs, slog.String("error", entry.err.Error()))
+	}
+	if entry.logBodies {
+		attrs = append(attrs,
+			slog.Any("request_headers", c.Redaction.MaskHeaders(entry.request.Header)),
+			slog.String("request_body", c.requestBody(entry.requestBody)),
+			slog.String("response_body", c.Redaction.MaskBody(entry.responseBody)),
+		)
+	}
+
+	c.Logger.LogAttrs(ctx, level, "flussonic api request", attrs...)
+}
+
+// requestBody returns the captured request body prepared for logging.
+func (c *baseClient) requestBody(capture *bodyCapture) string {
+	if capture == nil {
+		return ""
+	}
+	data, truncated := capture.bytes()
+	if truncated {
+		return fmt.Sprintf("[more than %d bytes of body]", maxCapturedBody)
+	}
+	return c.Redaction.MaskBody(data)
+}
+
+// bodyCapture records the beginning of a request body while it is sent.
+type bodyCapture struct {
+	io.ReadCloser
+	mu        sync.Mutex
+	data      []byte
+	truncated bool
+}
+
+// captureBody wraps the request body for logging if bodies are logged.
+func (c *baseClient) captureBody(request *http.Request, logBodies bool) *bodyCapture {
+	if !logBodies || c.Logger == nil || request.Body == nil || request.Body == http.NoBody {
+		return nil
+	}
+	capture := &bodyCapture{ReadCloser: request.Body}
+	request.Body = capture
+	return capture
+}
+
+// Read implements io.Reader.
+func (b *bodyCapture) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	room := maxCapturedBody - len(b.data)
+	if n > room {
+		b.truncated = true
+	}
+	b.data = append(b.data, p[:min(n, max(room, 0))]...)
+	b.mu.Unlock()
+	return n, err
+}
+
+func (b *bodyCapture) bytes() ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.data), b.truncated
+}
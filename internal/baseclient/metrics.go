@@ -0,0 +1,172 @@
+package baseclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/flussonic/go-flussonic/apierror"
+	"github.com/flussonic/go-flussonic/reqctx"
+)
+
+const meterName = "github.com/flussonic/go-flussonic"
+
+type operationKey struct{}
+
+// WithOperation returns a context carrying the logical operation name (e.g. StreamsList)
+// used to label metrics. If ctx already has an operation, it is kept, so the name
+// given by the outermost caller takes precedence.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	if _, ok := ctx.Value(operationKey{}).(string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// Operation returns the operation name given with reqctx.Operation or set with WithOperation.
+func Operation(ctx context.Context) string {
+	if options := reqctx.FromContext(ctx); options.Operation != "" {
+		return options.Operation
+	}
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// WithProduct sets the product name (e.g. flussonic, central) used to label metrics.
+func WithProduct(product string) Option {
+	return func(c *baseClient) {
+		c.Product = product
+	}
+}
+
+// WithMeterProvider sets the provider of SDK metrics, the global provider is used if nil.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *baseClient) {
+		if provider != nil {
+			c.Metrics = newMetrics(provider)
+		}
+	}
+}
+
+// metrics holds instruments recorded for every API call.
+type metrics struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+	retries  metric.Int64Counter
+	errors   metric.Int64Counter
+	items    metric.Int64Counter
+}
+
+// newMetrics creates instruments. Instrument creation errors are reported to the
+// global otel error handler and result in no-op instruments.
+func newMetrics(provider metric.MeterProvider) *metrics {
+	meter := provider.Meter(meterName)
+	m := &metrics{}
+	var err error
+	if m.requests, err = meter.Int64Counter("flussonic.client.requests",
+		metric.WithDescription("Number of API calls"),
+		metric.WithUnit("{request}")); err != nil {
+		otel.Handle(err)
+	}
+	if m.duration, err = meter.Float64Histogram("flussonic.client.request.duration",
+		metric.WithDescription("Duration of API calls including retries"),
+		metric.WithUnit("s")); err != nil {
+		otel.Handle(err)
+	}
+	if m.retries, err = meter.Int64Counter("flussonic.client.retries",
+		metric.WithDescription("Number of retried attempts"),
+		metric.WithUnit("{attempt}")); err != nil {
+		otel.Handle(err)
+	}
+	if m.errors, err = meter.Int64Counter("flussonic.client.errors",
+		metric.WithDescription("Number of failed API calls by error code"),
+		metric.WithUnit("{error}")); err != nil {
+		otel.Handle(err)
+	}
+	if m.items, err = meter.Int64Counter("flussonic.client.paginated_items",
+		metric.WithDescription("Number of items fetched from paginated collections"),
+		metric.WithUnit("{item}")); err != nil {
+		otel.Handle(err)
+	}
+	return m
+}
+
+// attributes returns labels identifying the operation.
+func (c *baseClient) attributes(ctx context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("product", c.Product),
+		attribute.String("operation", Operation(ctx)),
+	}
+}
+
+// recordCall records metrics of a finished call.
+func (c *baseClient) recordCall(ctx context.Context, started time.Time, attempts int, response *http.Response, err error) {
+	if c.Metrics == nil {
+		return
+	}
+	attrs := c.attributes(ctx)
+
+	status := 0
+	if response != nil {
+		status = response.StatusCode
+	} else if httpErr, ok := apierror.AsHTTPError(err); ok {
+		status = httpErr.StatusCode
+	}
+	withStatus := metric.WithAttributes(append(attrs, attribute.Int("status_code", status))...)
+
+	c.Metrics.requests.Add(ctx, 1, withStatus)
+	c.Metrics.duration.Record(ctx, time.Since(started).Seconds(), withStatus)
+	if attempts > 1 {
+		c.Metrics.retries.Add(ctx, int64(attempts-1), metric.WithAttributes(attrs...))
+	}
+	if err != nil {
+		c.Metrics.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.String("error_code", errorCode(err)))...))
+	}
+}
+
+// recordItems counts items of a decoded paginated collection.
+func (c *baseClient) recordItems(ctx context.Context, result any) {
+	if c.Metrics == nil {
+		return
+	}
+	if _, ok := result.(interface{ Next() *string }); !ok {
+		return
+	}
+	collection := reflect.ValueOf(result).MethodByName("Collection")
+	if !collection.IsValid() || collection.Type().NumIn() != 0 || collection.Type().NumOut() != 1 {
+		return
+	}
+	if items := collection.Call(nil)[0]; items.Kind() == reflect.Slice {
+		c.Metrics.items.Add(ctx, int64(items.Len()), metric.WithAttributes(c.attributes(ctx)...))
+	}
+}
+
+// errorCode returns a low cardinality code of the error: the API error code if the server
+// returned one, the HTTP status otherwise, or the kind of client side failure.
+func errorCode(err error) string {
+	if httpErr, ok := apierror.AsHTTPError(err); ok {
+		for _, apiErr := range httpErr.Errors() {
+			if apiErr.Code != nil && *apiErr.Code != "" {
+				return *apiErr.Code
+			}
+		}
+		return "http_" + strconv.Itoa(httpErr.StatusCode)
+	}
+	switch {
+	case errors.Is(err, apierror.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "transport"
+	}
+}
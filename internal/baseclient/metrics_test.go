@@ -0,0 +1,119 @@
+package baseclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/flussonic/go-flussonic/config"
+	"github.com/flussonic/go-flussonic/internal/baseclient"
+	"github.com/flussonic/go-flussonic/reqctx"
+)
+
+// pagedResult is a paginated collection as generated for list methods
+type pagedResult struct {
+	Items []string `json:"items"`
+}
+
+func (r *pagedResult) Next() *string        { return nil }
+func (r *pagedResult) Collection() []string { return r.Items }
+
+// collectSums returns values of all counters by metric name and attribute set
+func collectSums(ctx context.Context, reader *sdkmetric.ManualReader) (map[string]map[attribute.Distinct]int64, map[string]uint64, error) {
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &data); err != nil {
+		return nil, nil, err
+	}
+	sums := map[string]map[attribute.Distinct]int64{}
+	histograms := map[string]uint64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch d := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				sums[m.Name] = map[attribute.Distinct]int64{}
+				for _, point := range d.DataPoints {
+					sums[m.Name][point.Attributes.Equivalent()] = point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range d.DataPoints {
+					histograms[m.Name] += point.Count
+				}
+			}
+		}
+	}
+	return sums, histograms, nil
+}
+
+// TestRequest_Metrics tests that calls are counted with operation and product labels
+func (s *BaseClientTestSuite) TestRequest_Metrics() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"404","code":"stream_not_found"}]}`))
+		case attempts.Add(1) == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"items":["a","b","c"]}`))
+		}
+	}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	client := baseclient.New(nil, server.URL, nil, "", 1, append(
+		baseclient.ConfigOptions(&config.Config{MeterProvider: provider, RetryPolicy: &config.ExponentialBackoff{InitialDelay: time.Millisecond}}),
+		baseclient.WithProduct("flussonic"))...)
+
+	ctx := baseclient.WithOperation(context.Background(), "StreamsList")
+	req, err := http.NewRequest("GET", server.URL+"/streams", http.NoBody)
+	s.Require().NoError(err)
+	var result pagedResult
+	s.Require().NoError(client.Request(ctx, req, &result))
+
+	missingCtx := baseclient.WithOperation(context.Background(), "StreamGet")
+	req, err = http.NewRequest("GET", server.URL+"/missing", http.NoBody)
+	s.Require().NoError(err)
+	s.Require().Error(client.Request(missingCtx, req, nil))
+
+	sums, histograms, err := collectSums(context.Background(), reader)
+	s.Require().NoError(err)
+
+	key := func(operation string, extra ...attribute.KeyValue) attribute.Distinct {
+		set := attribute.NewSet(append([]attribute.KeyValue{
+			attribute.String("product", "flussonic"),
+			attribute.String("operation", operation),
+		}, extra...)...)
+		return set.Equivalent()
+	}
+
+	s.Require().Equal(map[attribute.Distinct]int64{
+		key("StreamsList", attribute.Int("status_code", http.StatusOK)):     1,
+		key("StreamGet", attribute.Int("status_code", http.StatusNotFound)): 1,
+	}, sums["flussonic.client.requests"])
+	s.Require().Equal(map[attribute.Distinct]int64{key("StreamsList"): 1}, sums["flussonic.client.retries"])
+	s.Require().Equal(map[attribute.Distinct]int64{key("StreamsList"): 3}, sums["flussonic.client.paginated_items"])
+	s.Require().Equal(map[attribute.Distinct]int64{
+		key("StreamGet", attribute.String("error_code", "stream_not_found")): 1,
+	}, sums["flussonic.client.errors"])
+	s.Require().Equal(uint64(2), histograms["flussonic.client.request.duration"])
+}
+
+// TestWithOperation tests that the outermost operation name is kept
+func (s *BaseClientTestSuite) TestWithOperation() {
+	s.T().Parallel()
+	ctx := baseclient.WithOperation(context.Background(), "MetricsGetParsed")
+	ctx = baseclient.WithOperation(ctx, "MetricsGetRaw")
+
+	s.Require().Equal("MetricsGetParsed", baseclient.Operation(ctx))
+	s.Require().Equal("CustomName", baseclient.Operation(reqctx.With(ctx, reqctx.Operation("CustomName"))))
+	s.Require().Empty(baseclient.Operation(context.Background()))
+}
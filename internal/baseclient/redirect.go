@@ -0,0 +1,35 @@
+package baseclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// maxRedirects is the limit of redirects followed by default, the same as in net/http.
+const maxRedirects = 10
+
+type noRedirectKey struct{}
+
+// WithoutRedirects returns a context for requests whose redirect responses are
+// returned to the caller instead of being followed, e.g. load balancer answers.
+func WithoutRedirects(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRedirectKey{}, true)
+}
+
+// checkRedirect wraps CheckRedirect of the HTTP client to stop at the first
+// redirect of requests made with WithoutRedirects context.
+func checkRedirect(next func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(request *http.Request, via []*http.Request) error {
+		if stop, _ := request.Context().Value(noRedirectKey{}).(bool); stop {
+			return http.ErrUseLastResponse
+		}
+		if next != nil {
+			return next(request, via)
+		}
+		if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package baseclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/flussonic/go-flussonic/upload"
+)
+
+// NewUploadRequest creates a request that streams body without buffering it in memory.
+// Content type and length are taken from body if it provides them (see upload.Body),
+// otherwise defaultContentType is used and the length is left unknown.
+// On retries the body is rewound if possible, otherwise the request is not retried.
+func NewUploadRequest(ctx context.Context, method, url, defaultContentType string, body io.Reader) (*http.Request, error) {
+	if body == nil {
+		body = http.NoBody
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	contentType := defaultContentType
+	if typed, ok := body.(interface{ ContentType() string }); ok && typed.ContentType() != "" {
+		contentType = typed.ContentType()
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	if sized, ok := body.(interface{ Length() int64 }); ok && sized.Length() >= 0 {
+		request.ContentLength = sized.Length()
+		if request.ContentLength == 0 {
+			request.Body = http.NoBody
+		}
+	}
+
+	if request.GetBody == nil && request.Body != http.NoBody {
+		request.GetBody = func() (io.ReadCloser, error) {
+			if err := upload.Rewind(body); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(body), nil
+		}
+	}
+
+	return request, nil
+}
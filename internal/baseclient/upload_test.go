@@ -0,0 +1,102 @@
+package baseclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/flussonic/go-flussonic/config"
+	"github.com/flussonic/go-flussonic/internal/baseclient"
+	"github.com/flussonic/go-flussonic/upload"
+)
+
+// TestUpload_StreamsBodyWithContentType tests that upload body metadata is sent
+func (s *BaseClientTestSuite) TestUpload_StreamsBodyWithContentType() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.Require().Equal("<tv></tv>", string(body))
+		s.Require().Equal("application/xml", r.Header.Get("Content-Type"))
+		s.Require().Equal(int64(9), r.ContentLength)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var sent int64
+	body := upload.New(strings.NewReader("<tv></tv>"), "application/xml", 9).
+		WithProgress(func(n, _ int64) { sent = n })
+	req, err := baseclient.NewUploadRequest(context.Background(), "POST", server.URL+"/xmltv", "application/octet-stream", body)
+	s.Require().NoError(err)
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int64(9), sent)
+}
+
+// TestUpload_DefaultContentType tests that plain readers use the default content type
+func (s *BaseClientTestSuite) TestUpload_DefaultContentType() {
+	s.T().Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().Equal("image/jpeg", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, err := baseclient.NewUploadRequest(context.Background(), "POST", server.URL+"/process", "image/jpeg", io.MultiReader(strings.NewReader("jpeg")))
+	s.Require().NoError(err)
+
+	client := baseclient.New(nil, server.URL, nil, "", 0)
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+}
+
+// TestUpload_RetryRewindsSeekableBody tests that seekable bodies are sent again on retry
+func (s *BaseClientTestSuite) TestUpload_RetryRewindsSeekableBody() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.Require().Equal("file-content", string(body))
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	body := upload.New(strings.NewReader("file-content"), "", 12)
+	req, err := baseclient.NewUploadRequest(context.Background(), "PUT", server.URL+"/files/a.mp4", "application/octet-stream", body)
+	s.Require().NoError(err)
+
+	policy := &config.ExponentialBackoff{InitialDelay: 1}
+	client := baseclient.New(nil, server.URL, nil, "", 2, baseclient.WithRetryPolicy(policy))
+	s.Require().NoError(client.Request(context.Background(), req, nil))
+	s.Require().Equal(int32(2), attempts.Load())
+}
+
+// TestUpload_NotRewindableBodyNotRetried tests that non-seekable streams are sent only once
+func (s *BaseClientTestSuite) TestUpload_NotRewindableBodyNotRetried() {
+	s.T().Parallel()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	body := upload.New(io.MultiReader(strings.NewReader("stream")), "", -1)
+	req, err := baseclient.NewUploadRequest(context.Background(), "PUT", server.URL+"/files/a.mp4", "application/octet-stream", body)
+	s.Require().NoError(err)
+
+	policy := &config.ExponentialBackoff{InitialDelay: 1}
+	client := baseclient.New(nil, server.URL, nil, "", 2, baseclient.WithRetryPolicy(policy))
+	err = client.Request(context.Background(), req, nil)
+
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "request failed after 1 attempts")
+	s.Require().Equal(int32(1), attempts.Load())
+}
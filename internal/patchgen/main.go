@@ -0,0 +1,94 @@
+// Command patchgen generates typed patch builders of a client package.
+//
+// Each argument after the flags describes a builder as "Name Model description",
+// e.g. "Stream StreamConfig a stream" generates StreamPatch, a patch.Builder of
+// model.StreamConfig passed to StreamSave, and NewStreamPatch. It is run with
+// go generate from the client package:
+//
+//	//go:generate go run ../internal/patchgen -model github.com/flussonic/go-flussonic/flussonic/model "Stream StreamConfig a stream"
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+type builder struct {
+	Name        string
+	Model       string
+	Description string
+}
+
+// Body returns the name of the unexported model type marshaling as the builder body.
+func (b builder) Body() string {
+	r, size := utf8.DecodeRuneInString(b.Name)
+	return string(unicode.ToLower(r)) + b.Name[size:] + "PatchBody"
+}
+
+var source = template.Must(template.New("patch").Parse(`// Code generated by internal/patchgen. DO NOT EDIT.
+package {{.Package}}
+
+import (
+	"encoding/json"
+
+	model "{{.Model}}"
+	"github.com/flussonic/go-flussonic/patch"
+)
+{{range .Builders}}
+// {{.Name}}Patch is a partial update of {{.Description}}, pass its Model to {{.Name}}Save.
+type {{.Name}}Patch = patch.Builder[model.{{.Model}}]
+
+// New{{.Name}}Patch creates an empty {{.Name}}Patch.
+func New{{.Name}}Patch() *{{.Name}}Patch {
+	tracked := &model.{{.Model}}Impl{}
+	return patch.New[model.{{.Model}}](tracked, func(body json.Marshaler) model.{{.Model}} {
+		return &{{.Body}}{{"{"}}{{.Model}}Impl: tracked, Marshaler: body}
+	})
+}
+
+// {{.Body}} returns the values set in the patch and marshals as its body.
+type {{.Body}} struct {
+	*model.{{.Model}}Impl
+	json.Marshaler
+}
+{{end}}`))
+
+func main() {
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	modelPath := flag.String("model", "", "import path of the model package")
+	output := flag.String("output", "patch_generated.go", "generated file")
+	flag.Parse()
+	if *pkg == "" || *modelPath == "" || flag.NArg() == 0 {
+		log.Fatal("usage: patchgen -package name -model path \"Name Model description\"...")
+	}
+
+	builders := make([]builder, flag.NArg())
+	for i, arg := range flag.Args() {
+		fields := strings.Fields(arg)
+		if len(fields) < 3 {
+			log.Fatalf("invalid builder %q, expected \"Name Model description\"", arg)
+		}
+		builders[i] = builder{Name: fields[0], Model: fields[1], Description: strings.Join(fields[2:], " ")}
+	}
+
+	var buf bytes.Buffer
+	err := source.Execute(&buf, map[string]any{"Package": *pkg, "Model": *modelPath, "Builders": builders})
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("failed to format generated code: %w", err))
+	}
+	if err := os.WriteFile(*output, data, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,30 @@
+// Package urlpath escapes parameters substituted into API request paths.
+package urlpath
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Segment escapes value so it is sent as a single path segment.
+// Slashes are escaped and dot segments are encoded, so value can not
+// address another resource or endpoint.
+func Segment(value string) string {
+	switch value {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(value)
+}
+
+// Subpath escapes value that may span several path segments, e.g. a file path
+// inside a storage. Slashes are kept, every segment is escaped with Segment.
+func Subpath(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = Segment(segment)
+	}
+	return strings.Join(segments, "/")
+}
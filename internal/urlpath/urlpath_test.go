@@ -0,0 +1,38 @@
+package urlpath_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/internal/urlpath"
+)
+
+func TestSegment(t *testing.T) {
+	t.Parallel()
+	for value, expected := range map[string]string{
+		"bunny":             "bunny",
+		"mylive/bunny":      "mylive%2Fbunny",
+		"my stream":         "my%20stream",
+		"камера":            "%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0",
+		"../../config":      "..%2F..%2Fconfig",
+		"..":                "%2E%2E",
+		".":                 "%2E",
+		"a?b#c%d":           "a%3Fb%23c%25d",
+		"name;drop=1&x=y+z": "name%3Bdrop=1&x=y+z",
+	} {
+		require.Equal(t, expected, urlpath.Segment(value), value)
+	}
+}
+
+func TestSubpath(t *testing.T) {
+	t.Parallel()
+	for value, expected := range map[string]string{
+		"movie.mp4":             "movie.mp4",
+		"dir/sub dir/movie.mp4": "dir/sub%20dir/movie.mp4",
+		"../../etc/passwd":      "%2E%2E/%2E%2E/etc/passwd",
+		"a/./b?x=1":             "a/%2E/b%3Fx=1",
+	} {
+		require.Equal(t, expected, urlpath.Subpath(value), value)
+	}
+}
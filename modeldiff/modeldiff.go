@@ -0,0 +1,249 @@
+// Package modeldiff compares, patches and merges generated models.
+//
+// Models are compared by their JSON form, so any model implementation, pointer
+// or map can be used. Unset fields are absent: nil pointers, nil values and zero
+// values of required fields, which are marshaled without omitempty. A pointer to
+// a zero value is set and differs from an unset field. Changes are reported with
+// JSON pointer paths (RFC 6901), e.g. /inputs/0/url.
+package modeldiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// RuntimeFields are fields holding runtime state of a server rather than
+// configuration, they are ignored at any depth by default.
+var RuntimeFields = []string{"stats"}
+
+// Change is a changed field.
+type Change struct {
+	// Path is the JSON pointer of the field, e.g. /inputs or /dvr/expiration
+	Path string
+	// Old is the old value, nil if the field is added
+	Old any
+	// New is the new value, nil if the field is removed
+	New any
+}
+
+// Option configures comparison.
+type Option func(*options)
+
+type options struct {
+	fields []string
+	paths  []string
+}
+
+// IgnoreFields ignores fields with the given names at any depth in addition to RuntimeFields.
+func IgnoreFields(names ...string) Option {
+	return func(o *options) {
+		o.fields = append(o.fields, names...)
+	}
+}
+
+// IgnorePaths ignores fields with the given JSON pointers, e.g. /name.
+func IgnorePaths(paths ...string) Option {
+	return func(o *options) {
+		o.paths = append(o.paths, paths...)
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{fields: slices.Clone(RuntimeFields)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// strip removes ignored fields from decoded JSON value at path.
+func (o *options) strip(path string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			fieldPath := path + "/" + escapePointer(key)
+			if slices.Contains(o.fields, key) || slices.Contains(o.paths, fieldPath) {
+				delete(value, key)
+				continue
+			}
+			o.strip(fieldPath, item)
+		}
+	case []any:
+		for i, item := range value {
+			o.strip(fmt.Sprintf("%s/%d", path, i), item)
+		}
+	}
+}
+
+// objects converts models into JSON objects without unset and ignored fields.
+func objects(opts []Option, models ...any) ([]map[string]any, error) {
+	o := newOptions(opts)
+	result := make([]map[string]any, 0, len(models))
+	for _, model := range models {
+		object, err := Object(model)
+		if err != nil {
+			return nil, err
+		}
+		o.strip("", object)
+		result = append(result, object)
+	}
+	return result, nil
+}
+
+// Object converts a model into a JSON object without unset fields.
+// A nil model is an empty object.
+func Object(model any) (map[string]any, error) {
+	value, err := toJSON(model)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return map[string]any{}, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("model is not a JSON object")
+	}
+	return object, nil
+}
+
+// Diff returns changes turning old into new sorted by path. Objects are compared
+// field by field, arrays of the same length element by element, other values as a whole.
+func Diff(old, new any, opts ...Option) ([]Change, error) {
+	pair, err := objects(opts, old, new)
+	if err != nil {
+		return nil, err
+	}
+	return diff("", pair[0], pair[1]), nil
+}
+
+// Equal reports whether models have no changes.
+func Equal(a, b any, opts ...Option) (bool, error) {
+	changes, err := Diff(a, b, opts...)
+	return len(changes) == 0, err
+}
+
+func diff(path string, old, new any) []Change {
+	switch oldValue := old.(type) {
+	case map[string]any:
+		if newValue, ok := new.(map[string]any); ok {
+			var changes []Change
+			for _, key := range slices.Sorted(maps.Keys(mergeKeys(oldValue, newValue))) {
+				changes = append(changes, diff(path+"/"+escapePointer(key), oldValue[key], newValue[key])...)
+			}
+			return changes
+		}
+	case []any:
+		if newValue, ok := new.([]any); ok && len(oldValue) == len(newValue) {
+			var changes []Change
+			for i := range oldValue {
+				changes = append(changes, diff(fmt.Sprintf("%s/%d", path, i), oldValue[i], newValue[i])...)
+			}
+			return changes
+		}
+	}
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	return []Change{{Path: path, Old: old, New: new}}
+}
+
+// toJSON converts a model into decoded JSON without unset fields.
+func toJSON(model any) (any, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal model: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode model: %w", err)
+	}
+	dropUnset(reflect.ValueOf(model), value)
+	return value, nil
+}
+
+// dropUnset removes null fields and zero values of struct fields without omitempty
+// from value decoded from JSON of v.
+func dropUnset(v reflect.Value, value any) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if item == nil {
+				delete(value, key)
+			}
+		}
+		switch {
+		case v.IsValid() && v.Kind() == reflect.Struct:
+			dropUnsetFields(v, value)
+		case v.IsValid() && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			for key, item := range value {
+				dropUnset(v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())), item)
+			}
+		default:
+			for _, item := range value {
+				dropUnset(reflect.Value{}, item)
+			}
+		}
+	case []any:
+		sequence := v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() == len(value)
+		for i, item := range value {
+			if sequence {
+				dropUnset(v.Index(i), item)
+			} else {
+				dropUnset(reflect.Value{}, item)
+			}
+		}
+	}
+}
+
+func dropUnsetFields(v reflect.Value, object map[string]any) {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			dropUnset(v.Field(i), object)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !slices.Contains(strings.Split(flags, ","), "omitempty") && v.Field(i).IsZero() {
+			delete(object, name)
+			continue
+		}
+		if item, ok := object[name]; ok {
+			dropUnset(v.Field(i), item)
+		}
+	}
+}
+
+func mergeKeys(a, b map[string]any) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+// escapePointer escapes a JSON pointer reference token.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
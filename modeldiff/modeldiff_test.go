@@ -0,0 +1,133 @@
+package modeldiff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/modeldiff"
+)
+
+// fetched is a stream as returned by the API with runtime fields.
+const fetched = `{
+	"name": "cam1",
+	"title": "Entrance",
+	"comment": "old",
+	"updated_at": 1700000000000,
+	"labels": {"site/zone": "north"},
+	"inputs": [
+		{"url": "rtsp://10.0.0.1/stream", "priority": 1, "stats": {"bitrate": 1000}},
+		{"url": "rtsp://10.0.0.2/stream", "priority": 2}
+	],
+	"dvr": {"reference": "dvr1", "expiration": 3600},
+	"stats": {"alive": true}
+}`
+
+func stream(t *testing.T, data string) *model.CentralStreamConfigImpl {
+	t.Helper()
+	s := &model.CentralStreamConfigImpl{}
+	require.NoError(t, json.Unmarshal([]byte(data), s))
+	return s
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	old := stream(t, fetched)
+	new := stream(t, fetched)
+	new.SetTitle("Main entrance")
+	new.CommentValue = nil
+	new.InputsValue[1].SetURL("rtsp://10.0.0.3/stream")
+	new.InputsValue[0].StatsValue = nil
+	new.LabelsValue["site/zone"] = "south"
+	new.DvrValue = nil
+	new.StatsValue = nil
+
+	changes, err := modeldiff.Diff(old, new, modeldiff.IgnorePaths("/updated_at"))
+	require.NoError(t, err)
+	require.Equal(t, []modeldiff.Change{
+		{Path: "/comment", Old: "old"},
+		{Path: "/dvr", Old: map[string]any{"reference": "dvr1", "expiration": float64(3600)}},
+		{Path: "/inputs/1/url", Old: "rtsp://10.0.0.2/stream", New: "rtsp://10.0.0.3/stream"},
+		{Path: "/labels/site~1zone", Old: "north", New: "south"},
+		{Path: "/title", Old: "Entrance", New: "Main entrance"},
+	}, changes)
+
+	equal, err := modeldiff.Equal(old, stream(t, fetched))
+	require.NoError(t, err)
+	require.True(t, equal)
+}
+
+func TestDiff_UnsetAndZero(t *testing.T) {
+	t.Parallel()
+
+	// Required fields with zero values are unset
+	object, err := modeldiff.Object(&model.CentralStreamConfigImpl{TitleValue: ptr("")})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"title": ""}, object)
+
+	// Pointer to zero value differs from unset field
+	changes, err := modeldiff.Diff(&model.CentralStreamConfigImpl{}, &model.CentralStreamConfigImpl{StaticValue: ptr(false)})
+	require.NoError(t, err)
+	require.Equal(t, []modeldiff.Change{{Path: "/static", New: false}}, changes)
+
+	// Null values of maps are unset
+	changes, err = modeldiff.Diff(map[string]any{"title": nil}, nil)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+	old := stream(t, fetched)
+	new := stream(t, fetched)
+	new.CommentValue = nil
+	new.InputsValue[1].SetPriority(3)
+	new.DvrValue.ExpirationValue = nil
+	new.StatsValue = nil
+
+	patch, err := modeldiff.Patch(old, new)
+	require.NoError(t, err)
+	data, err := json.Marshal(patch)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"comment": null,
+		"dvr": {"expiration": null},
+		"inputs": [
+			{"url": "rtsp://10.0.0.1/stream", "priority": 1},
+			{"url": "rtsp://10.0.0.2/stream", "priority": 3}
+		]
+	}`, string(data))
+
+	patch, err = modeldiff.Patch(old, stream(t, fetched))
+	require.NoError(t, err)
+	require.Empty(t, patch)
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	base := stream(t, fetched)
+
+	var partial model.CentralStreamConfig = &model.CentralStreamConfigImpl{TitleValue: ptr("Lobby")}
+	merged, err := modeldiff.Merge(model.CentralStreamConfig(base), partial)
+	require.NoError(t, err)
+	require.Equal(t, "Lobby", *merged.Title())
+	require.Equal(t, "old", *merged.Comment())
+	require.Len(t, merged.Inputs(), 2)
+	require.Equal(t, "Entrance", *base.Title())
+
+	removed, err := modeldiff.Merge(base, map[string]any{"comment": nil, "dvr": map[string]any{"expiration": nil}})
+	require.NoError(t, err)
+	require.Nil(t, removed.Comment())
+	require.Equal(t, "dvr1", string(*removed.Dvr().Reference()))
+	require.Nil(t, removed.Dvr().Expiration())
+
+	values, err := modeldiff.Merge(map[string]any{"a": 1.0, "b": map[string]any{"c": 2.0}}, map[string]any{"b": map[string]any{"d": 3.0}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"a": 1.0, "b": map[string]any{"c": 2.0, "d": 3.0}}, values)
+}
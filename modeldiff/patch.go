@@ -0,0 +1,104 @@
+package modeldiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Patch returns the minimal partial body turning old into new for Save methods
+// updating only passed fields. It is a JSON Merge Patch (RFC 7386): changed fields
+// are set, removed fields are null and arrays are replaced as a whole.
+// An empty patch means there are no changes.
+func Patch(old, new any, opts ...Option) (map[string]any, error) {
+	pair, err := objects(opts, old, new)
+	if err != nil {
+		return nil, err
+	}
+	return mergePatch(pair[0], pair[1]), nil
+}
+
+func mergePatch(old, new map[string]any) map[string]any {
+	patch := map[string]any{}
+	for key := range mergeKeys(old, new) {
+		oldValue, inOld := old[key]
+		newValue, inNew := new[key]
+		switch {
+		case !inNew:
+			patch[key] = nil
+		case !inOld:
+			patch[key] = newValue
+		default:
+			oldObject, oldIsObject := oldValue.(map[string]any)
+			newObject, newIsObject := newValue.(map[string]any)
+			if oldIsObject && newIsObject {
+				if nested := mergePatch(oldObject, newObject); len(nested) > 0 {
+					patch[key] = nested
+				}
+			} else if !reflect.DeepEqual(oldValue, newValue) {
+				patch[key] = newValue
+			}
+		}
+	}
+	return patch
+}
+
+// Merge returns a copy of base with patch applied. The patch is a partial model
+// where only set fields are merged, or a map where null values remove fields.
+// The result has the dynamic type of base, e.g. *model.StreamConfigImpl.
+func Merge[T any](base T, patch any) (T, error) {
+	var result T
+	object, err := Object(base)
+	if err != nil {
+		return result, err
+	}
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		if patchObject, err = Object(patch); err != nil {
+			return result, err
+		}
+	}
+
+	data, err := json.Marshal(applyMergePatch(object, patchObject))
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal merged model: %w", err)
+	}
+
+	baseType := reflect.TypeOf(base)
+	if baseType == nil {
+		return result, fmt.Errorf("base model is nil")
+	}
+	target := reflect.New(baseType)
+	if baseType.Kind() == reflect.Pointer {
+		target.Elem().Set(reflect.New(baseType.Elem()))
+		target = target.Elem()
+	}
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return result, fmt.Errorf("failed to decode merged model: %w", err)
+	}
+	if baseType.Kind() != reflect.Pointer {
+		target = target.Elem()
+	}
+	return target.Interface().(T), nil
+}
+
+// applyMergePatch applies a JSON Merge Patch to target.
+func applyMergePatch(target, patch map[string]any) map[string]any {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchObject, isObject := value.(map[string]any)
+		if !isObject {
+			target[key] = value
+			continue
+		}
+		targetObject, ok := target[key].(map[string]any)
+		if !ok {
+			targetObject = map[string]any{}
+		}
+		target[key] = applyMergePatch(targetObject, patchObject)
+	}
+	return target
+}
@@ -0,0 +1,150 @@
+package openmetrics
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Row is a set of values sharing the same labels, e.g. all metrics of one stream.
+type Row struct {
+	Labels map[string]string
+	// Values are keyed by sample name
+	Values map[string]float64
+}
+
+// Rows groups samples of all families by their label sets in order of first appearance.
+func Rows(families []*Family) []*Row {
+	var rows []*Row
+	byKey := make(map[string]*Row)
+	for _, family := range families {
+		for _, sample := range family.Samples {
+			key := labelsKey(sample.Labels)
+			row, ok := byKey[key]
+			if !ok {
+				row = &Row{Labels: sample.Labels, Values: map[string]float64{}}
+				byKey[key] = row
+				rows = append(rows, row)
+			}
+			row.Values[sample.Name] = sample.Value
+		}
+	}
+	return rows
+}
+
+// labelsKey returns a string uniquely identifying a label set.
+func labelsKey(labels map[string]string) string {
+	var key strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		key.WriteString(name)
+		key.WriteByte(0)
+		key.WriteString(labels[name])
+		key.WriteByte(0)
+	}
+	return key.String()
+}
+
+// Decode fills the struct pointed to by v from the row.
+// Fields tagged `openmetrics_label:"x"` are set from label x. Other fields are set
+// from the value named after their json tag (also with the counter `_total` suffix)
+// or, if there is no such value, from the label with the same name.
+// Fields of unsupported kinds and fields missing in the row are left untouched.
+func (r *Row) Decode(v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("openmetrics: decode target must be a non-nil struct pointer, got %T", v)
+	}
+	target = target.Elem()
+
+	for i := range target.NumField() {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if label, ok := field.Tag.Lookup("openmetrics_label"); ok {
+			if text, ok := r.Labels[label]; ok {
+				if err := setText(target.Field(i), text); err != nil {
+					return fmt.Errorf("openmetrics: label %s: %w", label, err)
+				}
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if value, ok := r.value(name); ok {
+			setNumber(target.Field(i), value)
+		} else if text, ok := r.Labels[name]; ok {
+			if err := setText(target.Field(i), text); err != nil {
+				return fmt.Errorf("openmetrics: label %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Row) value(name string) (float64, bool) {
+	if value, ok := r.Values[name]; ok {
+		return value, true
+	}
+	value, ok := r.Values[name+"_total"]
+	return value, ok
+}
+
+// settable allocates pointer fields and returns the value to set.
+func settable(field reflect.Value) reflect.Value {
+	if field.Kind() != reflect.Pointer {
+		return field
+	}
+	value := reflect.New(field.Type().Elem())
+	field.Set(value)
+	return value.Elem()
+}
+
+func setNumber(field reflect.Value, value float64) {
+	switch elemKind(field) {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		settable(field).SetInt(int64(value))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		settable(field).SetUint(uint64(value))
+	case reflect.Float32, reflect.Float64:
+		settable(field).SetFloat(value)
+	case reflect.Bool:
+		settable(field).SetBool(value != 0)
+	}
+}
+
+func setText(field reflect.Value, text string) error {
+	switch elemKind(field) {
+	case reflect.String:
+		settable(field).SetString(text)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("bad boolean %q", text)
+		}
+		settable(field).SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("bad number %q", text)
+		}
+		setNumber(field, value)
+	}
+	return nil
+}
+
+func elemKind(field reflect.Value) reflect.Kind {
+	if field.Kind() == reflect.Pointer {
+		return field.Type().Elem().Kind()
+	}
+	return field.Kind()
+}
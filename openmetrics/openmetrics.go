@@ -0,0 +1,216 @@
+// Package openmetrics parses the Prometheus text and OpenMetrics exposition formats
+// served by Flussonic products and decodes samples into model types using their
+// `openmetrics_label` struct tags.
+package openmetrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned for lines that are not valid exposition format.
+var ErrSyntax = errors.New("invalid metrics exposition")
+
+// Metric types as declared by # TYPE lines.
+const (
+	TypeUnknown   = "unknown"
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+	TypeSummary   = "summary"
+	TypeInfo      = "info"
+)
+
+// Sample is a single metric value with its labels.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Family groups samples of one metric with its metadata.
+type Family struct {
+	Name    string
+	Type    string
+	Help    string
+	Unit    string
+	Samples []Sample
+}
+
+// suffixes are sample name suffixes belonging to the family without them.
+var suffixes = []string{"_total", "_created", "_count", "_sum", "_bucket", "_gcount", "_gsum", "_info"}
+
+// Parse reads metric families from r. Timestamps and exemplars are skipped.
+// Samples not preceded by metadata form families of TypeUnknown.
+func Parse(r io.Reader) ([]*Family, error) {
+	var families []*Family
+	byName := make(map[string]*Family)
+	family := func(name string) *Family {
+		if f, ok := byName[name]; ok {
+			return f
+		}
+		f := &Family{Name: name, Type: TypeUnknown}
+		byName[name] = f
+		families = append(families, f)
+		return f
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "#") {
+			fields := strings.SplitN(strings.TrimSpace(text[1:]), " ", 3)
+			if fields[0] == "EOF" {
+				break
+			}
+			if len(fields) < 3 {
+				// Plain comment or metadata without value
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				family(fields[1]).Help = unescape(fields[2])
+			case "TYPE":
+				family(fields[1]).Type = fields[2]
+			case "UNIT":
+				family(fields[1]).Unit = fields[2]
+			}
+			continue
+		}
+
+		sample, err := parseSample(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		f := family(familyName(sample.Name, byName))
+		f.Samples = append(f.Samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	return families, nil
+}
+
+// familyName returns the name of a declared family the sample belongs to, or the sample name itself.
+func familyName(name string, declared map[string]*Family) string {
+	if _, ok := declared[name]; ok {
+		return name
+	}
+	for _, suffix := range suffixes {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			if _, ok := declared[base]; ok {
+				return base
+			}
+		}
+	}
+	return name
+}
+
+// parseSample parses `name{label="value",...} value [timestamp] [# exemplar]`.
+func parseSample(text string) (Sample, error) {
+	sample := Sample{Labels: map[string]string{}}
+
+	end := strings.IndexAny(text, "{ \t")
+	if end <= 0 {
+		return sample, fmt.Errorf("%w: missing value in %q", ErrSyntax, text)
+	}
+	sample.Name = text[:end]
+	if !validName(sample.Name) {
+		return sample, fmt.Errorf("%w: bad metric name %q", ErrSyntax, sample.Name)
+	}
+	rest := text[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return sample, err
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, fmt.Errorf("%w: missing value for %s", ErrSyntax, sample.Name)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("%w: bad value %q for %s", ErrSyntax, fields[0], sample.Name)
+	}
+	sample.Value = value
+
+	return sample, nil
+}
+
+// parseLabels parses labels after the opening brace into labels and returns the text after the closing brace.
+func parseLabels(text string, labels map[string]string) (string, error) {
+	for {
+		text = strings.TrimLeft(text, " \t")
+		if strings.HasPrefix(text, "}") {
+			return text[1:], nil
+		}
+
+		eq := strings.IndexByte(text, '=')
+		if eq <= 0 {
+			return "", fmt.Errorf("%w: bad label in %q", ErrSyntax, text)
+		}
+		name := strings.TrimSpace(text[:eq])
+		if !validName(name) {
+			return "", fmt.Errorf("%w: bad label name %q", ErrSyntax, name)
+		}
+		text = strings.TrimLeft(text[eq+1:], " \t")
+		if !strings.HasPrefix(text, `"`) {
+			return "", fmt.Errorf("%w: unquoted value of label %s", ErrSyntax, name)
+		}
+
+		var value strings.Builder
+		i := 1
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] == '\\' && i+1 < len(text) {
+				i++
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(text[i])
+				}
+				continue
+			}
+			value.WriteByte(text[i])
+		}
+		if i == len(text) {
+			return "", fmt.Errorf("%w: unterminated value of label %s", ErrSyntax, name)
+		}
+		labels[name] = value.String()
+
+		text = strings.TrimLeft(text[i+1:], " \t")
+		text = strings.TrimPrefix(text, ",")
+	}
+}
+
+func validName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_' || r == ':' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+func unescape(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(text)
+}
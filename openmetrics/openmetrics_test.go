@@ -0,0 +1,107 @@
+package openmetrics_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/openmetrics"
+)
+
+const exposition = `# HELP decoded_frames_count The number of decoded frames
+# TYPE decoded_frames_count counter
+decoded_frames_count_total{media="cam1",server_id="42"} 100
+decoded_frames_count_total{media="all",server_id="42"} 250 1700000000000
+# TYPE detection_time gauge
+detection_time{media="cam1",server_id="42"} 12.5
+detection_time{server_id="42",media="all"} NaN
+# a plain comment
+untyped_metric{name="a \"quoted\" \\ name\nline",} +Inf # {trace_id="x"} 1
+# EOF
+ignored 1
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	families, err := openmetrics.Parse(strings.NewReader(exposition))
+	require.NoError(t, err)
+	require.Len(t, families, 3)
+
+	frames := families[0]
+	require.Equal(t, "decoded_frames_count", frames.Name)
+	require.Equal(t, openmetrics.TypeCounter, frames.Type)
+	require.Equal(t, "The number of decoded frames", frames.Help)
+	require.Equal(t, []openmetrics.Sample{
+		{Name: "decoded_frames_count_total", Labels: map[string]string{"media": "cam1", "server_id": "42"}, Value: 100},
+		{Name: "decoded_frames_count_total", Labels: map[string]string{"media": "all", "server_id": "42"}, Value: 250},
+	}, frames.Samples)
+
+	require.Equal(t, openmetrics.TypeGauge, families[1].Type)
+	require.True(t, math.IsNaN(families[1].Samples[1].Value))
+
+	untyped := families[2]
+	require.Equal(t, openmetrics.TypeUnknown, untyped.Type)
+	require.Equal(t, "a \"quoted\" \\ name\nline", untyped.Samples[0].Labels["name"])
+	require.True(t, math.IsInf(untyped.Samples[0].Value, 1))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, text := range []string{
+		"metric",
+		"metric{a=\"1\"}",
+		"metric{a=1} 1",
+		"metric{a=\"1} 1",
+		"1metric 1",
+		"metric abc",
+	} {
+		_, err := openmetrics.Parse(strings.NewReader(text))
+		require.ErrorIs(t, err, openmetrics.ErrSyntax, text)
+	}
+}
+
+type streamMetrics struct {
+	Name          string   `json:"name" openmetrics_label:"media"`
+	DeviceID      *int     `json:"device_id,omitempty"`
+	DecodedFrames *int     `json:"decoded_frames_count,omitempty"`
+	DetectionTime *float64 `json:"detection_time,omitempty"`
+	Missing       *int     `json:"missing,omitempty"`
+}
+
+func TestRows_Decode(t *testing.T) {
+	t.Parallel()
+	families, err := openmetrics.Parse(strings.NewReader(`
+decoded_frames_count_total{media="cam1",device_id="1"} 100
+decoded_frames_count_total{media="all"} 250
+detection_time{device_id="1",media="cam1"} 12.5
+`))
+	require.NoError(t, err)
+
+	rows := openmetrics.Rows(families)
+	require.Len(t, rows, 2)
+
+	var stream streamMetrics
+	require.NoError(t, rows[0].Decode(&stream))
+	require.Equal(t, "cam1", stream.Name)
+	require.Equal(t, 1, *stream.DeviceID)
+	require.Equal(t, 100, *stream.DecodedFrames)
+	require.InEpsilon(t, 12.5, *stream.DetectionTime, 1e-9)
+	require.Nil(t, stream.Missing)
+
+	var worker streamMetrics
+	require.NoError(t, rows[1].Decode(&worker))
+	require.Equal(t, "all", worker.Name)
+	require.Nil(t, worker.DeviceID)
+	require.Equal(t, 250, *worker.DecodedFrames)
+}
+
+func TestRow_DecodeErrors(t *testing.T) {
+	t.Parallel()
+	row := &openmetrics.Row{Labels: map[string]string{"device_id": "gpu"}}
+
+	var metrics streamMetrics
+	require.Error(t, row.Decode(&metrics))
+	require.Error(t, row.Decode(metrics))
+}
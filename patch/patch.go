@@ -0,0 +1,195 @@
+// Package patch builds partial updates for Save methods which update only
+// the passed fields.
+//
+// A Builder tracks the fields set with the model setters and the fields cleared
+// explicitly, and marshals exactly them, so runtime and unchanged fields of a
+// fetched model are not sent back and concurrent changes of other fields are kept.
+// Client packages provide typed builders, e.g. flussonic.NewStreamPatch.
+//
+// Set fields are found by their values, as modeldiff.Object does: optional fields
+// are set once their setter is called, even with a zero value, while required
+// fields, e.g. name or url, are set only with non-zero values. Required fields set
+// to zero values are sent only when passed to Keep.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flussonic/go-flussonic/modeldiff"
+)
+
+// Builder collects fields of a partial update of a model of type T.
+type Builder[T any] struct {
+	model T
+	wrap  func(body json.Marshaler) T
+	nulls []string
+	kept  []string
+}
+
+// New creates a Builder collecting set fields in model, an empty model implementation.
+// wrap returns a model of type T reading the fields of model and marshaling as body,
+// it is used by Model. Client packages generate builders with internal/patchgen.
+func New[T any](model T, wrap func(body json.Marshaler) T) *Builder[T] {
+	return &Builder[T]{model: model, wrap: wrap}
+}
+
+// Set calls set with the tracked model, fields assigned by its setters are sent.
+func (b *Builder[T]) Set(set func(model T)) *Builder[T] {
+	set(b.model)
+	return b
+}
+
+// Null clears fields with the given JSON pointers, e.g. /comment or /dvr/expiration.
+// Cleared fields are sent as null even if they were set.
+func (b *Builder[T]) Null(paths ...string) *Builder[T] {
+	b.nulls = append(b.nulls, paths...)
+	return b
+}
+
+// Keep sends fields with the given JSON pointers with their values in the model,
+// including required fields set to zero values, e.g. /inputs/0/url set to "".
+func (b *Builder[T]) Keep(paths ...string) *Builder[T] {
+	b.kept = append(b.kept, paths...)
+	return b
+}
+
+// Empty reports whether no fields are set or cleared,
+// an error is returned for invalid field paths.
+func (b *Builder[T]) Empty() (bool, error) {
+	body, err := b.Body()
+	if err != nil {
+		return false, err
+	}
+	return len(body) == 0, nil
+}
+
+// Body returns the JSON object sent as the update.
+func (b *Builder[T]) Body() (map[string]any, error) {
+	body, err := modeldiff.Object(b.model)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.kept) > 0 {
+		data, err := json.Marshal(b.model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal model: %w", err)
+		}
+		var all map[string]any
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal model: %w", err)
+		}
+		for _, path := range b.kept {
+			value, err := lookup(all, path)
+			if err != nil {
+				return nil, err
+			}
+			if err := setPath(body, path, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, path := range b.nulls {
+		if err := setPath(body, path, nil); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// MarshalJSON marshals Body.
+func (b *Builder[T]) MarshalJSON() ([]byte, error) {
+	body, err := b.Body()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(body)
+}
+
+// Model returns a model marshaling as Body to pass to the Save method, its getters
+// return the values set. Errors of invalid paths are returned when the request
+// body is marshaled.
+func (b *Builder[T]) Model() T {
+	return b.wrap(b)
+}
+
+// setPath sets the field at JSON pointer path of object to value creating parent
+// objects, items of arrays must exist and are not created for missing parents.
+func setPath(object map[string]any, path string, value any) error {
+	tokens, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	var parent any = object
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+		switch container := parent.(type) {
+		case map[string]any:
+			if last {
+				container[token] = value
+				return nil
+			}
+			if container[token] == nil {
+				if _, err := strconv.Atoi(tokens[i+1]); err == nil {
+					return fmt.Errorf("invalid field path %q: no item %s", path, tokens[i+1])
+				}
+				container[token] = map[string]any{}
+			}
+			parent = container[token]
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(container) {
+				return fmt.Errorf("invalid field path %q: no item %s", path, token)
+			}
+			if last {
+				container[index] = value
+				return nil
+			}
+			parent = container[index]
+		default:
+			return fmt.Errorf("invalid field path %q: %s is not an object", path, tokens[i-1])
+		}
+	}
+	return nil
+}
+
+// lookup returns the value at JSON pointer path of object.
+func lookup(object map[string]any, path string) (any, error) {
+	tokens, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var value any = object
+	for _, token := range tokens {
+		switch parent := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = parent[token]; !ok {
+				return nil, fmt.Errorf("invalid field path %q: %s is not set", path, token)
+			}
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(parent) {
+				return nil, fmt.Errorf("invalid field path %q: no item %s", path, token)
+			}
+			value = parent[index]
+		default:
+			return nil, fmt.Errorf("invalid field path %q: %s is not an object", path, token)
+		}
+	}
+	return value, nil
+}
+
+// splitPath returns unescaped reference tokens of JSON pointer path.
+func splitPath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") || path == "/" {
+		return nil, fmt.Errorf("invalid field path %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,116 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/patch"
+)
+
+type streamBody struct {
+	*model.CentralStreamConfigImpl
+	json.Marshaler
+}
+
+func newStreamPatch() *patch.Builder[model.CentralStreamConfig] {
+	tracked := &model.CentralStreamConfigImpl{}
+	return patch.New[model.CentralStreamConfig](tracked, func(body json.Marshaler) model.CentralStreamConfig {
+		return &streamBody{CentralStreamConfigImpl: tracked, Marshaler: body}
+	})
+}
+
+func requireEmpty(t *testing.T, p *patch.Builder[model.CentralStreamConfig], expected bool) {
+	t.Helper()
+	empty, err := p.Empty()
+	require.NoError(t, err)
+	require.Equal(t, expected, empty)
+}
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+	p := newStreamPatch()
+	requireEmpty(t, p, true)
+
+	p.Set(func(s model.CentralStreamConfig) {
+		s.SetTitle("Entrance")
+		s.SetStatic(false)
+		s.SetLabels(map[string]model.UnixName{"zone": "north"})
+	}).Null("/comment", "/dvr/expiration", "/labels/site~1id")
+	requireEmpty(t, p, false)
+
+	// The model passed to Save methods marshals only set and cleared fields
+	data, err := json.Marshal(p.Model())
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"title": "Entrance",
+		"static": false,
+		"comment": null,
+		"dvr": {"expiration": null},
+		"labels": {"zone": "north", "site/id": null}
+	}`, string(data))
+
+	// Getters of the model return the values set
+	require.Equal(t, "Entrance", *p.Model().Title())
+	require.False(t, *p.Model().Static())
+}
+
+func TestBuilder_ZeroRequiredFields(t *testing.T) {
+	t.Parallel()
+	p := newStreamPatch().Set(func(s model.CentralStreamConfig) {
+		s.SetName("")
+		s.SetInputs([]model.StreamInput{&model.StreamInputImpl{}})
+	})
+
+	// Required fields set to zero values are not distinguished from unset ones
+	body, err := p.Body()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"inputs": []any{map[string]any{}}}, body)
+
+	body, err = p.Keep("/name", "/inputs/0/url").Body()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"name":   "",
+		"inputs": []any{map[string]any{"url": ""}},
+	}, body)
+
+	_, err = newStreamPatch().Keep("/inputs/0/url").Body()
+	require.EqualError(t, err, `invalid field path "/inputs/0/url": inputs is not set`)
+}
+
+func TestBuilder_NullOverridesSet(t *testing.T) {
+	t.Parallel()
+	p := newStreamPatch().Null("/title").Set(func(s model.CentralStreamConfig) {
+		s.SetTitle("Entrance")
+	})
+
+	body, err := p.Body()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"title": nil}, body)
+}
+
+func TestBuilder_InvalidPath(t *testing.T) {
+	t.Parallel()
+	for _, path := range []string{"comment", "/"} {
+		_, err := newStreamPatch().Null(path).Body()
+		require.Error(t, err, path)
+	}
+
+	// Parent of a cleared field must be an object
+	_, err := newStreamPatch().Set(func(s model.CentralStreamConfig) {
+		s.SetTitle("Entrance")
+	}).Null("/title/en").Body()
+	require.EqualError(t, err, `invalid field path "/title/en": title is not an object`)
+
+	// Items of missing arrays are not created as objects
+	_, err = newStreamPatch().Null("/inputs/0/url").Body()
+	require.EqualError(t, err, `invalid field path "/inputs/0/url": no item 0`)
+
+	_, err = json.Marshal(newStreamPatch().Null("comment").Model())
+	require.Error(t, err)
+
+	_, err = newStreamPatch().Null("comment").Empty()
+	require.Error(t, err)
+}
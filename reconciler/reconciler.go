@@ -0,0 +1,255 @@
+// Package reconciler applies a desired set of stream configurations to a Flussonic
+// media server or Central.
+//
+// Desired streams are compared field by field with the configuration stored on disk
+// (config_on_disk), not with the effective configuration returned by StreamsList,
+// so defaults and runtime values do not produce changes. Plan computes the changes
+// and prints as a dry-run report, Apply saves only changed fields of every stream
+// and reports errors per stream.
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/flussonic/go-flussonic/modeldiff"
+)
+
+// Target is a server storing stream configurations.
+type Target interface {
+	// ConfigsOnDisk returns configurations stored on disk of statically configured streams by name
+	ConfigsOnDisk(ctx context.Context) (map[string]map[string]any, error)
+	// Save creates the stream or updates fields of body, null fields are removed
+	Save(ctx context.Context, name string, body map[string]any) error
+	// Delete deletes the stream
+	Delete(ctx context.Context, name string) error
+}
+
+// Action is the change of a stream.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a changed field of a stream.
+type Change = modeldiff.Change
+
+// ignoredPaths are top-level fields of stream models which are not part of the configuration.
+var ignoredPaths = modeldiff.IgnorePaths("/name", "/config_on_disk", "/updated_at")
+
+// StreamPlan is the change of a single stream.
+type StreamPlan struct {
+	Name    string
+	Action  Action
+	Changes []Change
+
+	body map[string]any
+}
+
+// Plan is the set of changes turning the target into the desired state.
+type Plan struct {
+	// Streams are changed streams, created and updated ones first
+	Streams []StreamPlan
+	// Unchanged are names of desired streams matching the target
+	Unchanged []string
+}
+
+// Empty reports whether the target already matches the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Streams) == 0
+}
+
+// String formats the plan as a dry-run report.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, stream := range p.Streams {
+		switch stream.Action {
+		case ActionCreate:
+			fmt.Fprintf(&b, "+ %s\n", stream.Name)
+		case ActionUpdate:
+			fmt.Fprintf(&b, "~ %s\n", stream.Name)
+		case ActionDelete:
+			fmt.Fprintf(&b, "- %s\n", stream.Name)
+		}
+		for _, change := range stream.Changes {
+			switch {
+			case change.Old == nil:
+				fmt.Fprintf(&b, "    + %s: %s\n", change.Path, formatValue(change.New))
+			case change.New == nil:
+				fmt.Fprintf(&b, "    - %s: %s\n", change.Path, formatValue(change.Old))
+			default:
+				fmt.Fprintf(&b, "    ~ %s: %s -> %s\n", change.Path, formatValue(change.Old), formatValue(change.New))
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%d to change, %d unchanged\n", len(p.Streams), len(p.Unchanged))
+	return b.String()
+}
+
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Result is the outcome of applying the change of a stream.
+type Result struct {
+	Name   string
+	Action Action
+	Err    error
+}
+
+// Report are outcomes of applying a plan in the order of Plan.Streams.
+type Report []Result
+
+// Failed returns results of streams which were not changed.
+func (r Report) Failed() Report {
+	var failed Report
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err joins errors of all failed streams prefixed with stream names, nil if all succeeded.
+func (r Report) Err() error {
+	var errs []error
+	for _, result := range r {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s stream %s: %w", result.Action, result.Name, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Option configures a Reconciler.
+type Option func(*options)
+
+type options struct {
+	prune bool
+	match func(name string) bool
+}
+
+// WithPrune deletes streams on disk which are not desired. If match is not nil,
+// only matching streams are deleted, e.g. streams with a managed name prefix.
+func WithPrune(match func(name string) bool) Option {
+	return func(o *options) {
+		o.prune = true
+		o.match = match
+	}
+}
+
+// Reconciler makes streams of a target match desired stream models of type T.
+type Reconciler[T any] struct {
+	target  Target
+	options options
+}
+
+// New creates a Reconciler of target. T is the stream model, it must marshal to
+// a JSON object with a name field.
+func New[T any](target Target, opts ...Option) *Reconciler[T] {
+	r := &Reconciler[T]{target: target}
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+	return r
+}
+
+// Plan compares desired streams with configurations on disk of the target.
+func (r *Reconciler[T]) Plan(ctx context.Context, desired []T) (*Plan, error) {
+	wanted := make(map[string]map[string]any, len(desired))
+	for i, stream := range desired {
+		object, err := modeldiff.Object(stream)
+		if err != nil {
+			return nil, fmt.Errorf("invalid desired stream %d: %w", i, err)
+		}
+		name, _ := object["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("desired stream %d has no name", i)
+		}
+		if _, ok := wanted[name]; ok {
+			return nil, fmt.Errorf("duplicate desired stream %s", name)
+		}
+		wanted[name] = object
+	}
+
+	current, err := r.target.ConfigsOnDisk(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get streams: %w", err)
+	}
+
+	plan := &Plan{}
+	for _, name := range slices.Sorted(maps.Keys(wanted)) {
+		onDisk, exists := current[name]
+		changes, err := modeldiff.Diff(onDisk, wanted[name], ignoredPaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare stream %s: %w", name, err)
+		}
+		if exists && len(changes) == 0 {
+			plan.Unchanged = append(plan.Unchanged, name)
+			continue
+		}
+		body, err := modeldiff.Patch(onDisk, wanted[name], ignoredPaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare stream %s: %w", name, err)
+		}
+		action := ActionUpdate
+		if !exists {
+			action = ActionCreate
+		}
+		plan.Streams = append(plan.Streams, StreamPlan{Name: name, Action: action, Changes: changes, body: body})
+	}
+
+	if r.options.prune {
+		for _, name := range slices.Sorted(maps.Keys(current)) {
+			if _, ok := wanted[name]; ok {
+				continue
+			}
+			if r.options.match != nil && !r.options.match(name) {
+				continue
+			}
+			plan.Streams = append(plan.Streams, StreamPlan{Name: name, Action: ActionDelete})
+		}
+	}
+	return plan, nil
+}
+
+// Apply applies changes of the plan one stream at a time. A failed stream does not
+// stop the others, streams not applied before ctx is done fail with its error.
+func (r *Reconciler[T]) Apply(ctx context.Context, plan *Plan) Report {
+	report := make(Report, 0, len(plan.Streams))
+	for _, stream := range plan.Streams {
+		result := Result{Name: stream.Name, Action: stream.Action}
+		if result.Err = ctx.Err(); result.Err == nil {
+			switch stream.Action {
+			case ActionCreate, ActionUpdate:
+				result.Err = r.target.Save(ctx, stream.Name, stream.body)
+			case ActionDelete:
+				result.Err = r.target.Delete(ctx, stream.Name)
+			}
+		}
+		report = append(report, result)
+	}
+	return report
+}
+
+// Reconcile plans and applies the changes. The plan is returned for reporting.
+func (r *Reconciler[T]) Reconcile(ctx context.Context, desired []T) (*Plan, Report, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan, r.Apply(ctx, plan), nil
+}
@@ -0,0 +1,225 @@
+package reconciler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/central"
+	centralmodel "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/config"
+	"github.com/flussonic/go-flussonic/reconciler"
+)
+
+type stream = map[string]any
+
+// memoryTarget keeps configurations on disk and records saved bodies.
+type memoryTarget struct {
+	streams map[string]map[string]any
+	saved   map[string]map[string]any
+	deleted []string
+	fail    map[string]error
+}
+
+func (t *memoryTarget) ConfigsOnDisk(ctx context.Context) (map[string]map[string]any, error) {
+	return t.streams, nil
+}
+
+func (t *memoryTarget) Save(ctx context.Context, name string, body map[string]any) error {
+	if err := t.fail[name]; err != nil {
+		return err
+	}
+	if t.saved == nil {
+		t.saved = map[string]map[string]any{}
+	}
+	t.saved[name] = body
+	return nil
+}
+
+func (t *memoryTarget) Delete(ctx context.Context, name string) error {
+	if err := t.fail[name]; err != nil {
+		return err
+	}
+	t.deleted = append(t.deleted, name)
+	return nil
+}
+
+func newTarget() *memoryTarget {
+	return &memoryTarget{streams: map[string]map[string]any{
+		"cam1": {
+			"inputs":  []any{map[string]any{"url": "rtsp://10.0.0.1/stream"}},
+			"dvr":     map[string]any{"root": "/storage", "expiration": float64(3600)},
+			"comment": "entrance",
+		},
+		"cam2":   {"inputs": []any{map[string]any{"url": "rtsp://10.0.0.2/stream"}}},
+		"legacy": {"inputs": []any{map[string]any{"url": "fake://fake"}}},
+		"manual": {"inputs": []any{map[string]any{"url": "fake://fake"}}},
+	}}
+}
+
+func desired() []stream {
+	return []stream{
+		{
+			"name":   "cam1",
+			"inputs": []any{map[string]any{"url": "rtsp://10.0.0.1/stream"}},
+			"dvr":    map[string]any{"root": "/storage", "expiration": float64(7200)},
+			"stats":  map[string]any{"alive": true},
+		},
+		{"name": "cam2", "inputs": []any{map[string]any{"url": "rtsp://10.0.0.2/stream"}}},
+		{"name": "cam3", "inputs": []any{map[string]any{"url": "rtsp://10.0.0.3/stream"}}, "title": "Parking"},
+	}
+}
+
+func TestReconciler_Plan(t *testing.T) {
+	t.Parallel()
+	r := reconciler.New[stream](newTarget(), reconciler.WithPrune(func(name string) bool {
+		return name != "manual"
+	}))
+
+	plan, err := r.Plan(context.Background(), desired())
+	require.NoError(t, err)
+	require.False(t, plan.Empty())
+	require.Equal(t, []string{"cam2"}, plan.Unchanged)
+	require.Equal(t, []reconciler.StreamPlan{
+		{Name: "cam1", Action: reconciler.ActionUpdate, Changes: []reconciler.Change{
+			{Path: "/comment", Old: "entrance"},
+			{Path: "/dvr/expiration", Old: float64(3600), New: float64(7200)},
+		}},
+		{Name: "cam3", Action: reconciler.ActionCreate, Changes: []reconciler.Change{
+			{Path: "/inputs", New: []any{map[string]any{"url": "rtsp://10.0.0.3/stream"}}},
+			{Path: "/title", New: "Parking"},
+		}},
+		{Name: "legacy", Action: reconciler.ActionDelete},
+	}, stripBodies(plan.Streams))
+
+	require.Equal(t, `~ cam1
+    - /comment: "entrance"
+    ~ /dvr/expiration: 3600 -> 7200
++ cam3
+    + /inputs: [{"url":"rtsp://10.0.0.3/stream"}]
+    + /title: "Parking"
+- legacy
+3 to change, 1 unchanged
+`, plan.String())
+}
+
+// stripBodies copies plans without unexported request bodies.
+func stripBodies(streams []reconciler.StreamPlan) []reconciler.StreamPlan {
+	result := make([]reconciler.StreamPlan, 0, len(streams))
+	for _, s := range streams {
+		result = append(result, reconciler.StreamPlan{Name: s.Name, Action: s.Action, Changes: s.Changes})
+	}
+	return result
+}
+
+func TestReconciler_NoPrune(t *testing.T) {
+	t.Parallel()
+	target := newTarget()
+	r := reconciler.New[stream](target)
+
+	plan, err := r.Plan(context.Background(), desired())
+	require.NoError(t, err)
+	for _, s := range plan.Streams {
+		require.NotEqual(t, reconciler.ActionDelete, s.Action)
+	}
+}
+
+func TestReconciler_Apply(t *testing.T) {
+	t.Parallel()
+	target := newTarget()
+	target.fail = map[string]error{"cam3": errors.New("invalid input")}
+	r := reconciler.New[stream](target, reconciler.WithPrune(nil))
+
+	plan, report, err := r.Reconcile(context.Background(), desired())
+	require.NoError(t, err)
+	require.Len(t, report, len(plan.Streams))
+
+	// Only changed fields are sent, removed ones as null
+	require.Equal(t, map[string]map[string]any{
+		"cam1": {"comment": nil, "dvr": map[string]any{"expiration": float64(7200)}},
+	}, target.saved)
+	require.Equal(t, []string{"legacy", "manual"}, target.deleted)
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, "cam3", failed[0].Name)
+	require.EqualError(t, report.Err(), "failed to create stream cam3: invalid input")
+}
+
+func TestReconciler_Canceled(t *testing.T) {
+	t.Parallel()
+	target := newTarget()
+	r := reconciler.New[stream](target)
+
+	plan, err := r.Plan(context.Background(), desired())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := r.Apply(ctx, plan)
+	require.Len(t, report.Failed(), len(plan.Streams))
+	require.ErrorIs(t, report.Err(), context.Canceled)
+	require.Empty(t, target.saved)
+}
+
+func TestReconciler_InvalidDesired(t *testing.T) {
+	t.Parallel()
+	r := reconciler.New[stream](newTarget())
+
+	_, err := r.Plan(context.Background(), []stream{{"title": "no name"}})
+	require.EqualError(t, err, "desired stream 0 has no name")
+
+	_, err = r.Plan(context.Background(), []stream{{"name": "cam1"}, {"name": "cam1"}})
+	require.EqualError(t, err, "duplicate desired stream cam1")
+}
+
+func TestCentralTarget(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var requests []string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"streams":[
+				{"name":"cam1","inputs":[{"url":"rtsp://10.0.0.1/stream"}],"position":3,
+				 "config_on_disk":{"name":"cam1","inputs":[{"url":"rtsp://10.0.0.1/stream"}],"title":"Old"}},
+				{"name":"ephemeral","inputs":[{"url":"publish://"}]}
+			]}`)
+		case http.MethodPut:
+			body, _ = io.ReadAll(r.Body)
+			_, _ = io.WriteString(w, `{"name":"cam1"}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg, err := config.ParseURL(server.URL)
+	require.NoError(t, err)
+	client, err := central.New(cfg)
+	require.NoError(t, err)
+
+	desired := &centralmodel.CentralStreamConfigImpl{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"cam1","inputs":[{"url":"rtsp://10.0.0.1/stream"}]}`), desired))
+
+	r := reconciler.NewCentral(client)
+	plan, report, err := r.Reconcile(context.Background(), []centralmodel.CentralStreamConfig{desired})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	require.Equal(t, []reconciler.Change{{Path: "/title", Old: "Old"}}, plan.Streams[0].Changes)
+	require.JSONEq(t, `{"title":null}`, string(body))
+	require.True(t, strings.HasPrefix(requests[len(requests)-1], http.MethodPut+" /central/api/v3/streams/cam1"))
+}
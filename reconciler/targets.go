@@ -0,0 +1,130 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flussonic/go-flussonic/central"
+	centralmodel "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/flussonic"
+	flussonicmodel "github.com/flussonic/go-flussonic/flussonic/model"
+	"github.com/flussonic/go-flussonic/modeldiff"
+)
+
+// NewFlussonic creates a Reconciler of streams of a Flussonic media server.
+func NewFlussonic(client flussonic.Flussonic, opts ...Option) *Reconciler[flussonicmodel.StreamConfig] {
+	return New[flussonicmodel.StreamConfig](FlussonicTarget(client), opts...)
+}
+
+// NewCentral creates a Reconciler of streams managed by Central.
+func NewCentral(client central.Central, opts ...Option) *Reconciler[centralmodel.CentralStreamConfig] {
+	return New[centralmodel.CentralStreamConfig](CentralTarget(client), opts...)
+}
+
+// FlussonicTarget returns a Target of streams of a Flussonic media server.
+func FlussonicTarget(client flussonic.Flussonic) Target {
+	return &flussonicTarget{client: client}
+}
+
+type flussonicTarget struct {
+	client flussonic.Flussonic
+}
+
+func (t *flussonicTarget) ConfigsOnDisk(ctx context.Context) (map[string]map[string]any, error) {
+	streams := map[string]map[string]any{}
+	for stream, err := range t.client.StreamsListIterator(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		if err := addConfigOnDisk(streams, stream); err != nil {
+			return nil, err
+		}
+	}
+	return streams, nil
+}
+
+func (t *flussonicTarget) Save(ctx context.Context, name string, body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream: %w", err)
+	}
+	_, err = t.client.StreamSave(ctx, name, &rawStreamConfig{StreamConfigImpl: &flussonicmodel.StreamConfigImpl{}, body: data})
+	return err
+}
+
+func (t *flussonicTarget) Delete(ctx context.Context, name string) error {
+	return t.client.StreamDelete(ctx, name)
+}
+
+// rawStreamConfig is a stream model sent as a prepared body, so removed fields
+// are passed as null and unchanged fields are not passed at all.
+type rawStreamConfig struct {
+	*flussonicmodel.StreamConfigImpl
+	body json.RawMessage
+}
+
+func (s *rawStreamConfig) MarshalJSON() ([]byte, error) {
+	return s.body, nil
+}
+
+// CentralTarget returns a Target of streams managed by Central.
+func CentralTarget(client central.Central) Target {
+	return &centralTarget{client: client}
+}
+
+type centralTarget struct {
+	client central.Central
+}
+
+func (t *centralTarget) ConfigsOnDisk(ctx context.Context) (map[string]map[string]any, error) {
+	streams := map[string]map[string]any{}
+	for stream, err := range t.client.StreamsListIterator(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		if err := addConfigOnDisk(streams, stream); err != nil {
+			return nil, err
+		}
+	}
+	return streams, nil
+}
+
+func (t *centralTarget) Save(ctx context.Context, name string, body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream: %w", err)
+	}
+	_, err = t.client.StreamSave(ctx, name, &rawCentralStreamConfig{CentralStreamConfigImpl: &centralmodel.CentralStreamConfigImpl{}, body: data})
+	return err
+}
+
+func (t *centralTarget) Delete(ctx context.Context, name string) error {
+	return t.client.StreamDelete(ctx, name)
+}
+
+// rawCentralStreamConfig is a Central stream model sent as a prepared body.
+type rawCentralStreamConfig struct {
+	*centralmodel.CentralStreamConfigImpl
+	body json.RawMessage
+}
+
+func (s *rawCentralStreamConfig) MarshalJSON() ([]byte, error) {
+	return s.body, nil
+}
+
+// addConfigOnDisk adds config_on_disk of a listed stream to streams,
+// streams without it are not configured statically and are skipped.
+func addConfigOnDisk(streams map[string]map[string]any, stream any) error {
+	object, err := modeldiff.Object(stream)
+	if err != nil {
+		return err
+	}
+	name, _ := object["name"].(string)
+	onDisk, ok := object["config_on_disk"].(map[string]any)
+	if name == "" || !ok {
+		return nil
+	}
+	streams[name] = onDisk
+	return nil
+}
@@ -0,0 +1,103 @@
+package reqctx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/flussonic/go-flussonic/config"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type optionsContextKey struct{}
+
+// Options are per-call settings read by the base client from the request context.
+type Options struct {
+	// Timeout limits the whole call including retries, no limit if zero
+	Timeout time.Duration
+	// Retry overrides Config.Retry if not nil
+	Retry *uint
+	// RetryPolicy overrides Config.RetryPolicy if not nil
+	RetryPolicy config.RetryPolicy
+	// Headers are added to the request, they do not replace headers set by the client
+	Headers http.Header
+	// IdempotencyKey is sent in Idempotency-Key header and allows retrying non-idempotent methods
+	IdempotencyKey string
+	// Operation overrides the operation name used in metrics
+	Operation string
+	// NoLogBody disables logging of request and response bodies
+	NoLogBody bool
+}
+
+// Option configures Options of a call.
+type Option func(o *Options)
+
+// With returns a context with options applied on top of the options already attached to ctx.
+func With(ctx context.Context, opts ...Option) context.Context {
+	options := FromContext(ctx)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return context.WithValue(ctx, optionsContextKey{}, options)
+}
+
+// FromContext returns a copy of the options attached to ctx.
+func FromContext(ctx context.Context) Options {
+	options, _ := ctx.Value(optionsContextKey{}).(Options)
+	options.Headers = options.Headers.Clone()
+	return options
+}
+
+// Timeout limits the duration of the call including retries.
+func Timeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+// Retry sets the maximum number of retries after the initial attempt.
+func Retry(retry uint) Option {
+	return func(o *Options) {
+		o.Retry = &retry
+	}
+}
+
+// RetryPolicy sets the policy deciding which attempts of the call are retried.
+func RetryPolicy(policy config.RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = policy
+	}
+}
+
+// Header adds a header to the request unless the client sets it, e.g. Content-Type or Authorization.
+func Header(name, value string) Option {
+	return func(o *Options) {
+		if o.Headers == nil {
+			o.Headers = http.Header{}
+		}
+		o.Headers.Add(name, value)
+	}
+}
+
+// IdempotencyKey sets the idempotency key of the request.
+func IdempotencyKey(key string) Option {
+	return func(o *Options) {
+		o.IdempotencyKey = key
+	}
+}
+
+// Operation sets the operation name used in metrics instead of the method name.
+func Operation(name string) Option {
+	return func(o *Options) {
+		o.Operation = name
+	}
+}
+
+// NoLogBody disables logging of request and response bodies of the call.
+func NoLogBody() Option {
+	return func(o *Options) {
+		o.NoLogBody = true
+	}
+}
@@ -0,0 +1,146 @@
+// Package securetoken generates and verifies playback tokens of the Flussonic securetoken
+// auth scheme and builds playback URLs protected by them.
+//
+// A token has format hash-salt-endtime-starttime, where hash is
+// SHA-1 of stream + ip + starttime + endtime + key + salt.
+package securetoken
+
+import (
+	"crypto/rand"
+	// nolint:gosec
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NoCheckIP is signed instead of the client IP if the token is valid from any address.
+	NoCheckIP = "no_check_ip"
+	// DefaultLifetime is the token lifetime used by Generate if Params.End is zero.
+	DefaultLifetime = 3 * time.Hour
+	// DefaultDesync moves the start time to the past, so the token is accepted
+	// by a server with a slightly late clock.
+	DefaultDesync = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidToken is returned by Verify if the token is malformed or its hash does not match.
+	ErrInvalidToken = errors.New("invalid playback token")
+	// ErrTokenExpired is returned by Verify if the current time is outside of the token lifetime.
+	ErrTokenExpired = errors.New("playback token is expired or not yet valid")
+)
+
+// Params are signed into a token.
+type Params struct {
+	// Stream is the stream name, e.g. mylive/bunny
+	Stream string
+	// IP is the client address, NoCheckIP is used if empty
+	IP string
+	// Start is the beginning of the token lifetime, now minus DefaultDesync if zero
+	Start time.Time
+	// End is the end of the token lifetime, Start plus DefaultLifetime if zero
+	End time.Time
+	// Salt makes every token unique, a random one is generated if empty
+	Salt string
+}
+
+// Token is a parsed playback token.
+type Token struct {
+	Hash  string
+	Salt  string
+	Start time.Time
+	End   time.Time
+}
+
+// String returns the token in hash-salt-endtime-starttime format.
+func (t *Token) String() string {
+	return fmt.Sprintf("%s-%s-%d-%d", t.Hash, t.Salt, t.End.Unix(), t.Start.Unix())
+}
+
+// Generate returns a token for params signed with key.
+func Generate(key string, params Params) (*Token, error) {
+	if params.Stream == "" {
+		return nil, fmt.Errorf("stream is required")
+	}
+	if params.Start.IsZero() {
+		params.Start = time.Now().Add(-DefaultDesync)
+	}
+	if params.End.IsZero() {
+		params.End = params.Start.Add(DefaultLifetime)
+	}
+	if !params.End.After(params.Start) {
+		return nil, fmt.Errorf("end time %s is not after start time %s", params.End, params.Start)
+	}
+	if params.Salt == "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		params.Salt = hex.EncodeToString(salt)
+	}
+	if strings.Contains(params.Salt, "-") {
+		return nil, fmt.Errorf("salt must not contain '-'")
+	}
+
+	return &Token{
+		Hash:  hash(key, params.Stream, params.IP, params.Start.Unix(), params.End.Unix(), params.Salt),
+		Salt:  params.Salt,
+		Start: time.Unix(params.Start.Unix(), 0),
+		End:   time.Unix(params.End.Unix(), 0),
+	}, nil
+}
+
+// Parse parses a token in hash-salt-endtime-starttime format without verifying it.
+func Parse(token string) (*Token, error) {
+	parts := strings.Split(token, "-")
+	if len(parts) != 4 || parts[0] == "" {
+		return nil, fmt.Errorf("%w: expected hash-salt-endtime-starttime", ErrInvalidToken)
+	}
+	end, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end time %q", ErrInvalidToken, parts[2])
+	}
+	start, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start time %q", ErrInvalidToken, parts[3])
+	}
+	return &Token{
+		Hash:  parts[0],
+		Salt:  parts[1],
+		Start: time.Unix(start, 0),
+		End:   time.Unix(end, 0),
+	}, nil
+}
+
+// Verify checks that token was signed with key for stream and client ip
+// and that the current time is within its lifetime.
+func Verify(key, token, stream, ip string) (*Token, error) {
+	parsed, err := Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	expected := hash(key, stream, ip, parsed.Start.Unix(), parsed.End.Unix(), parsed.Salt)
+	if subtle.ConstantTimeCompare([]byte(strings.ToLower(parsed.Hash)), []byte(expected)) != 1 {
+		return nil, fmt.Errorf("%w: hash mismatch", ErrInvalidToken)
+	}
+	if now := time.Now(); now.Before(parsed.Start) || now.After(parsed.End) {
+		return nil, fmt.Errorf("%w: valid from %s to %s", ErrTokenExpired, parsed.Start, parsed.End)
+	}
+	return parsed, nil
+}
+
+// hash returns hex encoded SHA-1 of the signed fields.
+// nolint:gosec
+func hash(key, stream, ip string, start, end int64, salt string) string {
+	if ip == "" {
+		ip = NoCheckIP
+	}
+	hasher := sha1.New()
+	hasher.Write([]byte(stream + ip + strconv.FormatInt(start, 10) + strconv.FormatInt(end, 10) + key + salt))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,125 @@
+package securetoken_test
+
+import (
+	// nolint:gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/securetoken"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	start := time.Unix(1700000000, 0)
+	token, err := securetoken.Generate("secret", securetoken.Params{
+		Stream: "mylive/bunny",
+		IP:     "192.168.1.115",
+		Start:  start,
+		End:    start.Add(time.Hour),
+		Salt:   "abcdef",
+	})
+	require.NoError(t, err)
+
+	// nolint:gosec
+	sum := sha1.Sum([]byte("mylive/bunny192.168.1.11517000000001700003600secretabcdef"))
+	require.Equal(t, hex.EncodeToString(sum[:])+"-abcdef-1700003600-1700000000", token.String())
+
+	parsed, err := securetoken.Parse(token.String())
+	require.NoError(t, err)
+	require.Equal(t, token, parsed)
+}
+
+func TestGenerate_Defaults(t *testing.T) {
+	t.Parallel()
+	token, err := securetoken.Generate("secret", securetoken.Params{Stream: "bunny"})
+	require.NoError(t, err)
+	require.Len(t, token.Salt, 32)
+	require.WithinDuration(t, time.Now().Add(-securetoken.DefaultDesync), token.Start, 2*time.Second)
+	require.Equal(t, securetoken.DefaultLifetime, token.End.Sub(token.Start))
+
+	// Empty IP is signed as NoCheckIP
+	_, err = securetoken.Verify("secret", token.String(), "bunny", securetoken.NoCheckIP)
+	require.NoError(t, err)
+
+	other, err := securetoken.Generate("secret", securetoken.Params{Stream: "bunny"})
+	require.NoError(t, err)
+	require.NotEqual(t, token.Salt, other.Salt)
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	for name, params := range map[string]securetoken.Params{
+		"no stream":      {},
+		"end at start":   {Stream: "bunny", Start: now, End: now},
+		"dash in salt":   {Stream: "bunny", Salt: "a-b"},
+		"end before now": {Stream: "bunny", Start: now, End: now.Add(-time.Minute)},
+	} {
+		_, err := securetoken.Generate("secret", params)
+		require.Error(t, err, name)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	token, err := securetoken.Generate("secret", securetoken.Params{Stream: "bunny", IP: "10.0.0.1"})
+	require.NoError(t, err)
+
+	verified, err := securetoken.Verify("secret", token.String(), "bunny", "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, token, verified)
+
+	for name, check := range map[string][3]string{
+		"other key":    {"other", "bunny", "10.0.0.1"},
+		"other stream": {"secret", "cam1", "10.0.0.1"},
+		"other ip":     {"secret", "bunny", "10.0.0.2"},
+	} {
+		_, err := securetoken.Verify(check[0], token.String(), check[1], check[2])
+		require.ErrorIs(t, err, securetoken.ErrInvalidToken, name)
+	}
+
+	for _, malformed := range []string{"", "hash", "hash-salt-end-start", "hash-salt-1-start", "a-b-c-d-e"} {
+		_, err := securetoken.Verify("secret", malformed, "bunny", "10.0.0.1")
+		require.ErrorIs(t, err, securetoken.ErrInvalidToken, malformed)
+	}
+
+	expired, err := securetoken.Generate("secret", securetoken.Params{
+		Stream: "bunny",
+		Start:  time.Now().Add(-2 * time.Hour),
+		End:    time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = securetoken.Verify("secret", expired.String(), "bunny", "")
+	require.ErrorIs(t, err, securetoken.ErrTokenExpired)
+}
+
+func TestPlaybackURLs(t *testing.T) {
+	t.Parallel()
+	token := &securetoken.Token{Hash: "hash", Salt: "salt", Start: time.Unix(1, 0), End: time.Unix(2, 0)}
+
+	urls, err := securetoken.PlaybackURLs("https://streamer.example.com:8443/", "mylive/my bunny", token)
+	require.NoError(t, err)
+	require.Equal(t, &securetoken.URLs{
+		HLS:    "https://streamer.example.com:8443/mylive/my%20bunny/index.m3u8?token=hash-salt-2-1",
+		DASH:   "https://streamer.example.com:8443/mylive/my%20bunny/index.mpd?token=hash-salt-2-1",
+		WebRTC: "https://streamer.example.com:8443/mylive/my%20bunny/whep?token=hash-salt-2-1",
+		MSELD:  "wss://streamer.example.com:8443/mylive/my%20bunny/mse_ld?token=hash-salt-2-1",
+	}, urls)
+
+	urls, err = securetoken.PlaybackURLs("http://streamer.example.com", "bunny", token)
+	require.NoError(t, err)
+	require.Equal(t, "ws://streamer.example.com/bunny/mse_ld?token=hash-salt-2-1", urls.MSELD)
+
+	for _, server := range []string{"ftp://streamer.example.com", "streamer.example.com", "http://", "://"} {
+		_, err := securetoken.PlaybackURLs(server, "bunny", token)
+		require.Error(t, err, server)
+	}
+	_, err = securetoken.PlaybackURLs("http://streamer.example.com", "", token)
+	require.Error(t, err)
+	_, err = securetoken.PlaybackURLs("http://streamer.example.com", "bunny", nil)
+	require.EqualError(t, err, "token is required")
+}
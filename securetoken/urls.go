@@ -0,0 +1,59 @@
+package securetoken
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/flussonic/go-flussonic/internal/urlpath"
+)
+
+// URLs are playback URLs of a stream protected with a token.
+type URLs struct {
+	// HLS is the HLS playlist URL
+	HLS string
+	// DASH is the MPEG-DASH manifest URL
+	DASH string
+	// WebRTC is the WHEP endpoint URL for WebRTC playback
+	WebRTC string
+	// MSELD is the WebSocket URL for low-latency MSE playback
+	MSELD string
+}
+
+// PlaybackURLs returns playback URLs of stream on the server with token in the query.
+// Server is the base URL of the streamer, e.g. https://streamer.example.com:443.
+func PlaybackURLs(server, stream string, token *Token) (*URLs, error) {
+	base, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported server URL scheme %q", base.Scheme)
+	}
+	if base.Host == "" {
+		return nil, fmt.Errorf("server URL has no host")
+	}
+	if stream == "" {
+		return nil, fmt.Errorf("stream is required")
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	websocketScheme := "ws"
+	if base.Scheme == "https" {
+		websocketScheme = "wss"
+	}
+	query := url.Values{"token": {token.String()}}.Encode()
+	prefix := strings.TrimSuffix(base.EscapedPath(), "/") + "/" + urlpath.Subpath(stream) + "/"
+	build := func(scheme, endpoint string) string {
+		return scheme + "://" + base.Host + prefix + endpoint + "?" + query
+	}
+
+	return &URLs{
+		HLS:    build(base.Scheme, "index.m3u8"),
+		DASH:   build(base.Scheme, "index.mpd"),
+		WebRTC: build(base.Scheme, "whep"),
+		MSELD:  build(websocketScheme, "mse_ld"),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+)
+
+// base64Chunk is the number of source bytes encoded at once, a multiple of 3
+// so padding is only added to the last chunk.
+const base64Chunk = 3 * 1024
+
+// Base64JSON is a streaming JSON object body carrying the content of a reader
+// as a base64 encoded string field, e.g. {"content_type":"image/png","content":"iVBORw0..."},
+// for APIs accepting files inside JSON documents. The content is encoded while
+// the body is read and is never buffered in memory.
+type Base64JSON struct {
+	source io.Reader
+	prefix []byte
+	suffix []byte
+	length int64
+
+	reader  io.Reader
+	in      []byte
+	out     []byte
+	started bool
+}
+
+// NewBase64JSON creates a body of a JSON object with the content of source
+// in the field named field and the other fields with string values.
+// If source provides its length (see Body), the length of the body is known.
+func NewBase64JSON(source io.Reader, field string, fields map[string]string) (*Base64JSON, error) {
+	prefix := []byte{'{'}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		data, err := json.Marshal(map[string]string{name: fields[name]})
+		if err != nil {
+			return nil, err
+		}
+		prefix = append(prefix, data[1:len(data)-1]...)
+		prefix = append(prefix, ',')
+	}
+	name, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+	prefix = append(append(prefix, name...), ':', '"')
+
+	b := &Base64JSON{source: source, prefix: prefix, suffix: []byte(`"}`), length: -1}
+	if sized, ok := source.(interface{ Length() int64 }); ok && sized.Length() >= 0 {
+		b.length = int64(len(b.prefix)+len(b.suffix)) + int64(base64.StdEncoding.EncodedLen(int(sized.Length())))
+	}
+	b.reset()
+	return b, nil
+}
+
+// ContentType returns the MIME type of the body.
+func (b *Base64JSON) ContentType() string {
+	return "application/json"
+}
+
+// Length returns the body length in bytes, -1 if unknown.
+func (b *Base64JSON) Length() int64 {
+	return b.length
+}
+
+// Read implements io.Reader.
+func (b *Base64JSON) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Rewind rewinds the source so the body can be sent again, the source
+// is not required to be rewindable if none of its content was read.
+func (b *Base64JSON) Rewind() error {
+	if b.started {
+		if err := Rewind(b.source); err != nil {
+			return err
+		}
+	}
+	b.reset()
+	return nil
+}
+
+func (b *Base64JSON) reset() {
+	b.started = false
+	b.out = b.out[:0]
+	b.reader = io.MultiReader(bytes.NewReader(b.prefix), readerFunc(b.readContent), bytes.NewReader(b.suffix))
+}
+
+// readContent reads the next chunk of source encoded with base64.
+func (b *Base64JSON) readContent(p []byte) (int, error) {
+	b.started = true
+	if len(b.out) == 0 {
+		if b.in == nil {
+			b.in = make([]byte, base64Chunk)
+		}
+		n, err := io.ReadFull(b.source, b.in)
+		if n > 0 {
+			b.out = base64.StdEncoding.AppendEncode(b.out[:0], b.in[:n])
+		}
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			if n == 0 {
+				return 0, io.EOF
+			}
+		case err != nil:
+			return 0, err
+		}
+	}
+	n := copy(p, b.out)
+	b.out = b.out[n:]
+	return n, nil
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,101 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const DefaultContentType = "application/octet-stream"
+
+// ErrNotRewindable is returned when a body has to be sent again but its reader is not seekable.
+var ErrNotRewindable = errors.New("upload body is not rewindable")
+
+// ProgressFunc receives the number of bytes sent so far and the total length (-1 if unknown).
+type ProgressFunc func(sent, total int64)
+
+// Body is a streaming request body with known content type and length.
+// It is passed to upload methods as io.Reader and is never buffered in memory.
+// If the underlying reader implements io.Seeker, the body is rewound on retries.
+type Body struct {
+	reader      io.Reader
+	contentType string
+	length      int64
+	sent        int64
+	onProgress  ProgressFunc
+}
+
+// New creates a Body from reader. Length may be -1 if it is not known,
+// empty contentType is replaced with DefaultContentType.
+func New(reader io.Reader, contentType string, length int64) *Body {
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+	return &Body{
+		reader:      reader,
+		contentType: contentType,
+		length:      length,
+	}
+}
+
+// FromFile creates a Body from an opened file taking its length from file info.
+func FromFile(file *os.File, contentType string) (*Body, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return New(file, contentType, info.Size()), nil
+}
+
+// WithProgress sets the callback called after every chunk is read by the HTTP transport.
+func (b *Body) WithProgress(fn ProgressFunc) *Body {
+	b.onProgress = fn
+	return b
+}
+
+// ContentType returns the MIME type of the body.
+func (b *Body) ContentType() string {
+	return b.contentType
+}
+
+// Length returns the body length in bytes, -1 if unknown.
+func (b *Body) Length() int64 {
+	return b.length
+}
+
+// Read implements io.Reader and reports progress.
+func (b *Body) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	if n > 0 {
+		b.sent += int64(n)
+		if b.onProgress != nil {
+			b.onProgress(b.sent, b.length)
+		}
+	}
+	return n, err
+}
+
+// Rewind seeks the underlying reader to the start so the body can be sent again.
+func (b *Body) Rewind() error {
+	if err := Rewind(b.reader); err != nil {
+		return err
+	}
+	b.sent = 0
+	return nil
+}
+
+// Rewind seeks reader to the start. Readers implementing Rewind() error are rewound with it.
+func Rewind(reader io.Reader) error {
+	switch r := reader.(type) {
+	case interface{ Rewind() error }:
+		return r.Rewind()
+	case io.Seeker:
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind upload body: %w", err)
+		}
+		return nil
+	default:
+		return ErrNotRewindable
+	}
+}
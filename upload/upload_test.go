@@ -0,0 +1,124 @@
+package upload_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/upload"
+)
+
+func TestBody_Progress(t *testing.T) {
+	t.Parallel()
+	var reports [][2]int64
+	body := upload.New(strings.NewReader("0123456789"), "text/plain", 10).
+		WithProgress(func(sent, total int64) {
+			reports = append(reports, [2]int64{sent, total})
+		})
+
+	buf := make([]byte, 4)
+	var out bytes.Buffer
+	_, err := io.CopyBuffer(struct{ io.Writer }{&out}, struct{ io.Reader }{body}, buf)
+	require.NoError(t, err)
+
+	require.Equal(t, "0123456789", out.String())
+	require.Equal(t, [][2]int64{{4, 10}, {8, 10}, {10, 10}}, reports)
+	require.Equal(t, "text/plain", body.ContentType())
+	require.Equal(t, int64(10), body.Length())
+}
+
+func TestBody_DefaultContentType(t *testing.T) {
+	t.Parallel()
+	body := upload.New(strings.NewReader(""), "", -1)
+
+	require.Equal(t, upload.DefaultContentType, body.ContentType())
+	require.Equal(t, int64(-1), body.Length())
+}
+
+func TestBody_Rewind(t *testing.T) {
+	t.Parallel()
+	var lastSent int64
+	body := upload.New(strings.NewReader("abc"), "", 3).WithProgress(func(sent, _ int64) { lastSent = sent })
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(data))
+	require.Equal(t, int64(3), lastSent)
+
+	require.NoError(t, body.Rewind())
+	data, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(data))
+	require.Equal(t, int64(3), lastSent)
+}
+
+func TestBody_NotRewindable(t *testing.T) {
+	t.Parallel()
+	body := upload.New(io.MultiReader(strings.NewReader("abc")), "", -1)
+
+	require.ErrorIs(t, body.Rewind(), upload.ErrNotRewindable)
+}
+
+func TestFromFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "movie.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("not really a movie"), 0o600))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	body, err := upload.FromFile(file, "video/mp4")
+	require.NoError(t, err)
+	require.Equal(t, int64(18), body.Length())
+	require.Equal(t, "video/mp4", body.ContentType())
+}
+
+func TestBase64JSON(t *testing.T) {
+	t.Parallel()
+	content := bytes.Repeat([]byte("logo\x00\xff"), 1000)
+	var sent int64
+	source := upload.New(bytes.NewReader(content), "image/png", int64(len(content))).
+		WithProgress(func(s, total int64) { sent = s })
+
+	body, err := upload.NewBase64JSON(source, "content", map[string]string{"content_type": source.ContentType()})
+	require.NoError(t, err)
+	require.Equal(t, "application/json", body.ContentType())
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, body.Length(), int64(len(data)))
+	require.Equal(t, int64(len(content)), sent)
+
+	var decoded struct {
+		Content     []byte `json:"content"`
+		ContentType string `json:"content_type"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, content, decoded.Content)
+	require.Equal(t, "image/png", decoded.ContentType)
+
+	// Retries send the same body again
+	require.NoError(t, body.Rewind())
+	again, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
+
+func TestBase64JSON_NotRewindable(t *testing.T) {
+	t.Parallel()
+	body, err := upload.NewBase64JSON(struct{ io.Reader }{strings.NewReader("a")}, "content", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), body.Length())
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"content":"YQ=="}`, string(data))
+	require.ErrorIs(t, body.Rewind(), upload.ErrNotRewindable)
+}
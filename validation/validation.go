@@ -0,0 +1,292 @@
+// Package validation checks models against the constraints of their API schemas
+// before they are sent.
+//
+// The generated model implementations carry the constraints in validate struct
+// tags: required, omitempty, oneof, base64, min, max and dive. Validate evaluates
+// them and reports each violated constraint as an apierror.Error whose source
+// points to the field with a JSON pointer, the same way the API reports rejected
+// request documents.
+package validation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flussonic/go-flussonic/apierror"
+)
+
+// Error lists the fields of a model violating its constraints.
+type Error struct {
+	Errors []apierror.Error
+}
+
+// Error makes Error compatible with the error interface.
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldErr.Pointer(), *fieldErr.Title)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Pointers returns JSON pointers of the invalid fields.
+func (e *Error) Pointers() []string {
+	pointers := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		pointers[i] = fieldErr.Pointer()
+	}
+	return pointers
+}
+
+// Option configures Validate.
+type Option func(*validator)
+
+// IgnorePaths skips fields with the given JSON pointers, e.g. /updated_at which
+// is required in fetched models but assigned by the server on save.
+func IgnorePaths(paths ...string) Option {
+	return func(v *validator) {
+		v.ignored = append(v.ignored, paths...)
+	}
+}
+
+// Partial does not enforce required fields, for partial updates which
+// pass only changed fields.
+func Partial() Option {
+	return func(v *validator) {
+		v.partial = true
+	}
+}
+
+// Validate checks model, a generated model or a pointer to it, against its validate
+// tags and nested models recursively. It returns *Error listing all violated
+// constraints or nil. Unknown constraints are not checked.
+func Validate(model any, opts ...Option) error {
+	v := &validator{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.walk("", reflect.ValueOf(model))
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &Error{Errors: v.errors}
+}
+
+type validator struct {
+	ignored []string
+	partial bool
+	errors  []apierror.Error
+}
+
+// walk validates fields of nested models in value located at path.
+func (v *validator) walk(path string, value reflect.Value) {
+	value = indirect(value)
+	if !value.IsValid() {
+		return
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		v.walkStruct(path, value)
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			v.walk(path+"/"+strconv.Itoa(i), value.Index(i))
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for _, key := range sortedKeys(value) {
+			v.walk(path+"/"+escape(key.String()), value.MapIndex(key))
+		}
+	}
+}
+
+func (v *validator) walkStruct(path string, value reflect.Value) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if field.Anonymous {
+				v.walk(path, value.Field(i))
+			}
+			continue
+		}
+		fieldPath := path + "/" + escape(name)
+		if slices.Contains(v.ignored, fieldPath) {
+			continue
+		}
+		if tag, ok := field.Tag.Lookup("validate"); ok {
+			v.check(fieldPath, value.Field(i), strings.Split(tag, ","))
+		}
+		v.walk(fieldPath, value.Field(i))
+	}
+}
+
+// check applies rules of a validate tag to value located at path.
+func (v *validator) check(path string, value reflect.Value, rules []string) {
+	for i, rule := range rules {
+		rule, param, _ := strings.Cut(rule, "=")
+		switch rule {
+		case "omitempty":
+			if isEmpty(value) {
+				return
+			}
+		case "required":
+			if isEmpty(value) {
+				if !v.partial {
+					v.fail(path, rule, "is required")
+				}
+				return
+			}
+		case "dive":
+			value = indirect(value)
+			switch value.Kind() {
+			case reflect.Slice, reflect.Array:
+				for j := range value.Len() {
+					v.check(path+"/"+strconv.Itoa(j), value.Index(j), rules[i+1:])
+				}
+			case reflect.Map:
+				for _, key := range sortedKeys(value) {
+					v.check(path+"/"+escape(key.String()), value.MapIndex(key), rules[i+1:])
+				}
+			}
+			return
+		default:
+			if message := checkRule(indirect(value), rule, param); message != "" {
+				v.fail(path, rule, message)
+				return
+			}
+		}
+	}
+}
+
+func (v *validator) fail(path, rule, message string) {
+	v.errors = append(v.errors, apierror.Error{
+		Source: &apierror.ErrorSource{Pointer: &path},
+		Title:  &message,
+		Code:   &rule,
+	})
+}
+
+// checkRule returns the message of a violated rule or an empty string.
+func checkRule(value reflect.Value, rule, param string) string {
+	if !value.IsValid() {
+		return ""
+	}
+	switch rule {
+	case "oneof":
+		s, ok := scalar(value)
+		if ok && !slices.Contains(strings.Fields(param), s) {
+			return fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(param), ", "))
+		}
+	case "base64":
+		if value.Kind() != reflect.String {
+			return ""
+		}
+		if _, err := base64.StdEncoding.DecodeString(value.String()); err != nil {
+			return "must be base64 encoded"
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return ""
+		}
+		size, unit, ok := measure(value)
+		if !ok {
+			return ""
+		}
+		if rule == "min" && size < limit {
+			return fmt.Sprintf("must be at least %s%s", formatFloat(limit), unit)
+		}
+		if rule == "max" && size > limit {
+			return fmt.Sprintf("must be at most %s%s", formatFloat(limit), unit)
+		}
+	}
+	return ""
+}
+
+// measure returns the number checked by min and max: the value of numbers,
+// the length of strings, slices and maps.
+func measure(value reflect.Value) (float64, string, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), " items", true
+	}
+	return 0, "", false
+}
+
+// scalar formats a string, number or boolean value as in oneof lists.
+func scalar(value reflect.Value) (string, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(value.Float()), true
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	}
+	return "", false
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// isEmpty reports whether value is unset: nil or the zero value.
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+// indirect dereferences pointers and interfaces, it returns the zero Value for nil.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// sortedKeys returns keys of a map with string keys in order, so errors are reported in a stable order.
+func sortedKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return keys
+}
+
+// escape escapes a JSON pointer reference token.
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,100 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/flussonic/go-flussonic/apierror"
+	model "github.com/flussonic/go-flussonic/central/model"
+	"github.com/flussonic/go-flussonic/validation"
+)
+
+func stream(t *testing.T, data string) *model.CentralStreamConfigImpl {
+	t.Helper()
+	s := &model.CentralStreamConfigImpl{}
+	require.NoError(t, json.Unmarshal([]byte(data), s))
+	return s
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	s := stream(t, `{
+		"name": "cam1",
+		"updated_at": 1700000000000,
+		"labels": {"site/zone": "north"},
+		"inputs": [{"url": "rtsp://10.0.0.1/stream", "pixel": 10}],
+		"dvbocr": "replace"
+	}`)
+	require.NoError(t, validation.Validate(s))
+	require.NoError(t, validation.Validate(model.CentralStreamConfig(s)))
+}
+
+func TestValidate_Errors(t *testing.T) {
+	t.Parallel()
+	s := stream(t, `{
+		"name": "cam1",
+		"updated_at": 1700000000,
+		"labels": {"site/zone": "", "rack": "r1"},
+		"inputs": [
+			{"url": "rtsp://10.0.0.1/stream"},
+			{"pixel": "rgb16", "passphrase": "short"}
+		],
+		"dvbocr": "append",
+		"max_retry_timeout": 0
+	}`)
+
+	err := validation.Validate(s)
+	var validationErr *validation.Error
+	require.ErrorAs(t, err, &validationErr)
+	require.ElementsMatch(t, []string{
+		"/updated_at",
+		"/labels/site~1zone",
+		"/inputs/1/url",
+		"/inputs/1/pixel",
+		"/inputs/1/passphrase",
+		"/dvbocr",
+		"/max_retry_timeout",
+	}, validationErr.Pointers())
+
+	byPointer := map[string]apierror.Error{}
+	for _, fieldErr := range validationErr.Errors {
+		byPointer[fieldErr.Pointer()] = fieldErr
+	}
+	require.Equal(t, "must be at least 1000000000000", *byPointer["/updated_at"].Title)
+	require.Equal(t, "min", *byPointer["/updated_at"].Code)
+	require.Equal(t, "is required", *byPointer["/inputs/1/url"].Title)
+	require.Equal(t, "must be one of: rgb8, rgb10, rgb12, yuv8, yuv10, 8, 10", *byPointer["/inputs/1/pixel"].Title)
+	require.Equal(t, "must be at least 10 characters", *byPointer["/inputs/1/passphrase"].Title)
+	require.Equal(t, "must be at least 1 characters", *byPointer["/labels/site~1zone"].Title)
+	require.Contains(t, err.Error(), "/dvbocr: must be one of: replace, add")
+}
+
+func TestValidate_Base64(t *testing.T) {
+	t.Parallel()
+	err := validation.Validate(&model.VisionFaceAttributesImpl{
+		FingerprintValue: &model.VisionFaceFingerprintImpl{DataValue: "not base64!", VersionValue: "v1"},
+	})
+	require.EqualError(t, err, "validation failed: /fingerprint/data: must be base64 encoded")
+
+	require.NoError(t, validation.Validate(&model.VisionFaceFingerprintImpl{DataValue: "AQID", VersionValue: "v1"}))
+}
+
+func TestValidate_Options(t *testing.T) {
+	t.Parallel()
+
+	// Fields assigned by the server are not set in new models
+	s := &model.CentralStreamConfigImpl{NameValue: "cam1"}
+	require.Error(t, validation.Validate(s))
+	require.NoError(t, validation.Validate(s, validation.IgnorePaths("/updated_at")))
+
+	// Required fields are not enforced for partial updates, set fields are
+	partial := &model.CentralStreamConfigImpl{InputsValue: []*model.StreamInputImpl{{}}}
+	require.NoError(t, validation.Validate(partial, validation.Partial()))
+	partial.InputsValue[0].PixelValue = "rgb16"
+	require.EqualError(t, validation.Validate(partial, validation.Partial()),
+		"validation failed: /inputs/0/pixel: must be one of: rgb8, rgb10, rgb12, yuv8, yuv10, 8, 10")
+
+	require.NoError(t, validation.Validate(nil))
+}
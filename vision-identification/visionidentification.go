@@ -16,6 +16,7 @@ import (
 	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
 	"github.com/flussonic/go-flussonic/internal/cursors"
+	"github.com/flussonic/go-flussonic/internal/urlpath"
 	model "github.com/flussonic/go-flussonic/vision-identification/model"
 )
 
@@ -94,7 +95,8 @@ type BaseClientFactory func(cfg *config.Config, apiURL string, authKey authoriza
 
 // defaultBaseClientFactory creates a default base client instance.
 func defaultBaseClientFactory(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
-	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry,
+		append(baseclient.ConfigOptions(cfg), baseclient.WithProduct("vision-identification"))...)
 }
 
 // Client is the concrete implementation of the VisionIdentification interface.
@@ -135,7 +137,15 @@ func NewWithBaseFactory(cfg *config.Config, baseFactory BaseClientFactory) (Visi
 		cfg.Originator = config.DefaultOriginator
 	}
 
-	base := baseFactory(cfg, apiURL, authKey)
+	httpClient, err := cfg.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+	// The caller's config is not changed, so it still builds a client from its settings when reused
+	withClient := *cfg
+	withClient.HTTPClient = httpClient
+
+	base := baseFactory(&withClient, apiURL, authKey)
 
 	return &Client{
 		base:   base,
@@ -225,6 +235,7 @@ func (c *Client) doList(ctx context.Context, path string, query interface{ ToQue
 // EpisodesIdentify Identify persons in episodes
 // Your application shall make this request to Identification service  to identify the face fingerprints by comparing them with the database of persons.  Supply the whole result of `episodes_list` to this method.  It will return a list of matched persons with corresponding match score.  Pay attention to `identification_error`.  Try to repeat the request for the episodes with errors in one or two minutes: the error may resolve because a better picture of face may be received in the same or other episodes.
 func (c *Client) EpisodesIdentify(ctx context.Context, body model.EpisodeIdentificationRequest) (model.IdentifiedEpisodesList, error) {
+	ctx = baseclient.WithOperation(ctx, "EpisodesIdentify")
 	path := "/vision/api/v3/episodes/identify"
 	result := &model.IdentifiedEpisodesListImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -236,6 +247,7 @@ func (c *Client) EpisodesIdentify(ctx context.Context, body model.EpisodeIdentif
 // IdentificationMetricsGet Prometheus metrics
 // Provides endpoint for Prometheus scraper.
 func (c *Client) IdentificationMetricsGet(ctx context.Context) (model.IdentificationMetrics, error) {
+	ctx = baseclient.WithOperation(ctx, "IdentificationMetricsGet")
 	path := "/vision/api/v3/monitoring/metrics"
 	result := &model.IdentificationMetricsImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -247,6 +259,7 @@ func (c *Client) IdentificationMetricsGet(ctx context.Context) (model.Identifica
 // LivenessProbe Liveness probe
 // Liveness probe.
 func (c *Client) LivenessProbe(ctx context.Context) (model.VisionServerInfo, error) {
+	ctx = baseclient.WithOperation(ctx, "LivenessProbe")
 	path := "/vision/api/v3/monitoring/liveness"
 	result := &model.VisionServerInfoImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -258,7 +271,8 @@ func (c *Client) LivenessProbe(ctx context.Context) (model.VisionServerInfo, err
 // PersonDelete Delete person
 // Delete a person with the supplied identifier
 func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
-	path := fmt.Sprintf("/vision/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonDelete")
+	path := fmt.Sprintf("/vision/api/v3/persons/%s", urlpath.Segment(person_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -268,7 +282,8 @@ func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
 // PersonGet Get person
 // Returns the person by its identifier
 func (c *Client) PersonGet(ctx context.Context, person_id string) (model.VisionPerson, error) {
-	path := fmt.Sprintf("/vision/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonGet")
+	path := fmt.Sprintf("/vision/api/v3/persons/%s", urlpath.Segment(person_id))
 	result := &model.VisionPersonImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -279,7 +294,8 @@ func (c *Client) PersonGet(ctx context.Context, person_id string) (model.VisionP
 // PersonSave Save person
 // Create or update existing person by its identifier
 func (c *Client) PersonSave(ctx context.Context, person_id string, body model.VisionPerson) (model.VisionPerson, error) {
-	path := fmt.Sprintf("/vision/api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonSave")
+	path := fmt.Sprintf("/vision/api/v3/persons/%s", urlpath.Segment(person_id))
 	result := &model.VisionPersonImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -290,6 +306,7 @@ func (c *Client) PersonSave(ctx context.Context, person_id string, body model.Vi
 // PersonsList List of persons
 // Returns a list of persons
 func (c *Client) PersonsList(ctx context.Context, query *PersonsListQuery) (model.VisionPersonsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PersonsList")
 	path := "/vision/api/v3/persons"
 	result := &model.VisionPersonsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
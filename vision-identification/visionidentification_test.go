@@ -292,6 +292,52 @@ func TestClient_PersonsList(t *testing.T) {
 	verifyMarshalUnmarshal(t, result, expectedJSON)
 }
 
+func TestClient_PersonGetEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for personID, expected := range map[string]string{
+		"1/../2":        "/vision/api/v3/persons/1%2F..%2F2",
+		"..":            "/vision/api/v3/persons/%2E%2E",
+		"id with space": "/vision/api/v3/persons/id%20with%20space",
+		"id?x=1":        "/vision/api/v3/persons/id%3Fx=1",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.PersonGet(ctx, personID); err != nil {
+			t.Fatalf("PersonGet(%q) failed: %v", personID, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("PersonGet(%q): expected path %s, got %s", personID, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("PersonGet(%q): unexpected query %q", personID, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string
@@ -0,0 +1,57 @@
+package visioninference
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/flussonic/go-flussonic/openmetrics"
+	model "github.com/flussonic/go-flussonic/vision-inference/model"
+)
+
+// MediaAll is the media label of per-worker rows aggregating metrics of all streams served by the worker.
+const MediaAll = "all"
+
+// Metrics are Prometheus metrics of the vision inference server decoded into model types.
+type Metrics struct {
+	// Labels common to all rows, e.g. server ID.
+	Labels model.OpenmetricsLabels
+	// Streams contains per-stream rows.
+	Streams []model.VisionMetrics
+	// Workers contains per-worker rows marked with media=all.
+	Workers []model.VisionMetrics
+}
+
+// ParseMetrics parses metrics in Prometheus text exposition format as served by MetricsGetRaw.
+// Rows without the media label are skipped.
+func ParseMetrics(r io.Reader) (*Metrics, error) {
+	families, err := openmetrics.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	labels := &model.OpenmetricsLabelsImpl{}
+	result := &Metrics{Labels: labels}
+	for _, row := range openmetrics.Rows(families) {
+		media, ok := row.Labels["media"]
+		if !ok {
+			continue
+		}
+		if labels.ServerIDValue == nil {
+			if err := row.Decode(labels); err != nil {
+				return nil, fmt.Errorf("failed to decode metrics labels: %w", err)
+			}
+		}
+
+		metrics := &model.VisionMetricsImpl{}
+		if err := row.Decode(metrics); err != nil {
+			return nil, fmt.Errorf("failed to decode metrics of %s: %w", media, err)
+		}
+		if media == MediaAll {
+			result.Workers = append(result.Workers, metrics)
+		} else {
+			result.Streams = append(result.Streams, metrics)
+		}
+	}
+
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"iter"
 	"net/http"
 	"net/url"
@@ -40,9 +41,16 @@ type VisionInference interface {
 	// MetricsGet Prometheus metrics
 	// Provides endpoint for Prometheus scraper. Each record represents per-stream metrics.   Additionally there is a bunch of per-worker records containing aggregation of metrics of streams served by this worker.   Per-worker metrics are marked with `media=all` attribute.  JSON representation of metrics is not implemented.   Its schema can be used for getting the list of metrics with descriptions for reference
 	MetricsGet(ctx context.Context) (model.VisionMetrics, error)
+	// MetricsGetParsed Prometheus metrics decoded into per-stream and per-worker rows
+	// Per-worker rows are the ones marked with `media=all` attribute.
+	MetricsGetParsed(ctx context.Context) (*Metrics, error)
+	// MetricsGetRaw Prometheus metrics as raw text
+	// Returns the metrics in Prometheus text exposition format.
+	// The caller must close the returned body.
+	MetricsGetRaw(ctx context.Context) (io.ReadCloser, error)
 	// Process Image analysis
 	// Analyzes the supplied image. Detects objects and computes digital fingerprints of the detected objects (if fingerprints are supported for the object type).
-	Process(ctx context.Context) (model.VisionProcessResult, error)
+	Process(ctx context.Context, body io.Reader) (model.VisionProcessResult, error)
 	// StatsGet Server info and runtime stats
 	// Provides information about running instance such as version, available hardware and utilization
 	StatsGet(ctx context.Context) (model.VisionWorkerStats, error)
@@ -222,7 +230,8 @@ type BaseClientFactory func(cfg *config.Config, apiURL string, authKey authoriza
 
 // defaultBaseClientFactory creates a default base client instance.
 func defaultBaseClientFactory(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
-	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry,
+		append(baseclient.ConfigOptions(cfg), baseclient.WithProduct("vision-inference"))...)
 }
 
 // Client is the concrete implementation of the VisionInference interface.
@@ -263,7 +272,15 @@ func NewWithBaseFactory(cfg *config.Config, baseFactory BaseClientFactory) (Visi
 		cfg.Originator = config.DefaultOriginator
 	}
 
-	base := baseFactory(cfg, apiURL, authKey)
+	httpClient, err := cfg.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+	// The caller's config is not changed, so it still builds a client from its settings when reused
+	withClient := *cfg
+	withClient.HTTPClient = httpClient
+
+	base := baseFactory(&withClient, apiURL, authKey)
 
 	return &Client{
 		base:   base,
@@ -324,6 +341,51 @@ func (c *Client) doDelete(ctx context.Context, path string) error {
 	return c.base.Request(ctx, request, nil)
 }
 
+// doUpload performs a request streaming a raw body and unmarshals the response into result.
+// contentType is used unless body provides its own (see upload.Body).
+func (c *Client) doUpload(ctx context.Context, method, path, contentType string, body io.Reader, result any) error {
+	request, err := baseclient.NewUploadRequest(ctx, method, c.apiURL+path, contentType, body)
+	if err != nil {
+		return err
+	}
+	return c.base.Request(ctx, request, result)
+}
+
+// doStream performs a GET request with query parameters and returns the raw response body.
+// The caller must close the returned body.
+func (c *Client) doStream(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, accept string) (io.ReadCloser, error) {
+	if query != nil {
+		var err error
+		if path, err = withQuery(path, query); err != nil {
+			return nil, err
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	response, err := c.base.RequestStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, query interface{ ToQueryString() (string, error) }) (string, error) {
+	queryString, err := query.ToQueryString()
+	if err != nil {
+		return "", fmt.Errorf("failed to build query string: %w", err)
+	}
+	if queryString != "" {
+		path += "?" + queryString
+	}
+	return path, nil
+}
+
 // doList performs a GET request with query parameters and unmarshals the response into result.
 func (c *Client) doList(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, result any) error {
 	if query == nil {
@@ -353,6 +415,7 @@ func (c *Client) doList(ctx context.Context, path string, query interface{ ToQue
 // ConfigGet Get configuration
 // Returns the current configuration of the analytics modules at your server.
 func (c *Client) ConfigGet(ctx context.Context) (model.ConfigVision, error) {
+	ctx = baseclient.WithOperation(ctx, "ConfigGet")
 	path := "/vision/api/v3/config"
 	result := &model.ConfigVisionImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -364,6 +427,7 @@ func (c *Client) ConfigGet(ctx context.Context) (model.ConfigVision, error) {
 // CounterRecordsList Counter records list
 // The returned list includes records of counters of all types, i.e. humans, vehicles, etc. Each record represents aggregated metrics within some timeframe, a minute for instance. Specify a set of collection filters (such as media, region, period of time) to pick records of interest.
 func (c *Client) CounterRecordsList(ctx context.Context, query *CounterRecordsListQuery) (model.CounterRecordsList, error) {
+	ctx = baseclient.WithOperation(ctx, "CounterRecordsList")
 	path := "/vision/api/v3/counters/records"
 	result := &model.CounterRecordsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -380,6 +444,7 @@ func (c *Client) CounterRecordsListIterator(ctx context.Context, query *CounterR
 // EpisodesList Episodes list
 // Your client application shall make this long poll request to Inference node(s).  The request returns the list of episodes registered during operation on your server.  How to make long poll:  * Set `poll_timeout` and `updated_at_gt` in the query. * The episodes that match the `updated_at_gt` filter will be returned instantly (as in a regular request). * If there are no such episodes, the connection will last `poll_timeout` seconds. * If during this connection interval an episode appears that matches the `updated_at_gt` filter,  it is returned immediately.  The returned list includes all episodes, i.e. license plate recognition (LPR), face detection, etc. LPR results can be used right away while  face episodes shall be passed to the Identification service via `episodes_identify`  in order to match face fingerprints against the face database.  There may be several Inference nodes and one Identification service  to ensure that the same face is recognized on all your cameras.  Request all your Inference nodes before supplying the result to Identification.
 func (c *Client) EpisodesList(ctx context.Context, query *EpisodesListQuery) (model.EpisodesList, error) {
+	ctx = baseclient.WithOperation(ctx, "EpisodesList")
 	path := "/vision/api/v3/episodes"
 	result := &model.EpisodesListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -396,6 +461,7 @@ func (c *Client) EpisodesListIterator(ctx context.Context, query *EpisodesListQu
 // LivenessProbe Liveness probe
 // Liveness probe.
 func (c *Client) LivenessProbe(ctx context.Context) (model.VisionServerInfo, error) {
+	ctx = baseclient.WithOperation(ctx, "LivenessProbe")
 	path := "/vision/api/v3/monitoring/liveness"
 	result := &model.VisionServerInfoImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -407,6 +473,7 @@ func (c *Client) LivenessProbe(ctx context.Context) (model.VisionServerInfo, err
 // MetricsGet Prometheus metrics
 // Provides endpoint for Prometheus scraper. Each record represents per-stream metrics.   Additionally there is a bunch of per-worker records containing aggregation of metrics of streams served by this worker.   Per-worker metrics are marked with `media=all` attribute.  JSON representation of metrics is not implemented.   Its schema can be used for getting the list of metrics with descriptions for reference
 func (c *Client) MetricsGet(ctx context.Context) (model.VisionMetrics, error) {
+	ctx = baseclient.WithOperation(ctx, "MetricsGet")
 	path := "/vision/api/v3/monitoring/metrics"
 	result := &model.VisionMetricsImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -415,12 +482,34 @@ func (c *Client) MetricsGet(ctx context.Context) (model.VisionMetrics, error) {
 	return result, nil
 }
 
+// MetricsGetParsed Prometheus metrics decoded into per-stream and per-worker rows
+// Per-worker rows are the ones marked with `media=all` attribute.
+func (c *Client) MetricsGetParsed(ctx context.Context) (*Metrics, error) {
+	ctx = baseclient.WithOperation(ctx, "MetricsGetParsed")
+	body, err := c.MetricsGetRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ParseMetrics(body)
+}
+
+// MetricsGetRaw Prometheus metrics as raw text
+// Returns the metrics in Prometheus text exposition format.
+// The caller must close the returned body.
+func (c *Client) MetricsGetRaw(ctx context.Context) (io.ReadCloser, error) {
+	ctx = baseclient.WithOperation(ctx, "MetricsGetRaw")
+	path := "/vision/api/v3/monitoring/metrics"
+	return c.doStream(ctx, path, nil, "text/plain")
+}
+
 // Process Image analysis
 // Analyzes the supplied image. Detects objects and computes digital fingerprints of the detected objects (if fingerprints are supported for the object type).
-func (c *Client) Process(ctx context.Context) (model.VisionProcessResult, error) {
+func (c *Client) Process(ctx context.Context, body io.Reader) (model.VisionProcessResult, error) {
+	ctx = baseclient.WithOperation(ctx, "Process")
 	path := "/vision/api/v3/process"
 	result := &model.VisionProcessResultImpl{}
-	if err := c.doPost(ctx, path, nil, result); err != nil {
+	if err := c.doUpload(ctx, http.MethodPost, path, "image/jpeg", body, result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -429,6 +518,7 @@ func (c *Client) Process(ctx context.Context) (model.VisionProcessResult, error)
 // StatsGet Server info and runtime stats
 // Provides information about running instance such as version, available hardware and utilization
 func (c *Client) StatsGet(ctx context.Context) (model.VisionWorkerStats, error) {
+	ctx = baseclient.WithOperation(ctx, "StatsGet")
 	path := "/vision/api/v3/stats"
 	result := &model.VisionWorkerStatsImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -440,6 +530,7 @@ func (c *Client) StatsGet(ctx context.Context) (model.VisionWorkerStats, error)
 // StreamsList List streams
 // This API method allows to get information about streams being processed by the server.
 func (c *Client) StreamsList(ctx context.Context, query *StreamsListQuery) (model.StreamsList, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamsList")
 	path := "/vision/api/v3/streams"
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -243,12 +243,12 @@ func TestClient_Process(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.Process(ctx)
+	_, err := client.Process(ctx, bytes.NewReader([]byte{0xff, 0xd8, 0xff}))
 	if err != nil {
 		t.Fatalf("Process failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.Process(ctx)
+	result, err := client.Process(ctx, bytes.NewReader([]byte{0xff, 0xd8, 0xff}))
 	if err != nil {
 		t.Fatalf("Process failed: %v", err)
 	}
@@ -345,6 +345,114 @@ func TestClient_StreamsList(t *testing.T) {
 	verifyMarshalUnmarshal(t, result, expectedJSON)
 }
 
+func TestClient_ProcessSendsImage(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"episodes":[]}`}
+	client := createTestClient(t, rt)
+
+	image := []byte{0xff, 0xd8, 0xff, 0xe0}
+	if _, err := client.Process(ctx, bytes.NewReader(image)); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if !bytes.Equal(rt.body, image) {
+		t.Errorf("Expected image body, got %v", rt.body)
+	}
+	if got := rt.request.Header.Get("Content-Type"); got != "image/jpeg" {
+		t.Errorf("Expected image/jpeg content type, got %q", got)
+	}
+}
+
+func TestClient_MetricsGetRaw(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: "# TYPE frames counter\nframes_total 42\n"}
+	client := createTestClient(t, rt)
+
+	body, err := client.MetricsGetRaw(ctx)
+	if err != nil {
+		t.Fatalf("MetricsGetRaw failed: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(data) != "# TYPE frames counter\nframes_total 42\n" {
+		t.Errorf("Expected raw body, got %q", data)
+	}
+	if got := rt.request.Header.Get("Accept"); got != "text/plain" {
+		t.Errorf("Expected Accept text/plain, got %q", got)
+	}
+}
+
+func TestClient_MetricsGetParsed(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `# TYPE decoded_frames_count counter
+decoded_frames_count_total{server_id="6d1a2e7c-0000-4000-8000-000000000001",media="cam1",detector="face",device_id="0",hardware="gpu"} 100
+decoded_frames_count_total{server_id="6d1a2e7c-0000-4000-8000-000000000001",media="all",detector="face",device_id="0",hardware="gpu"} 250
+# TYPE processing_time gauge
+processing_time{server_id="6d1a2e7c-0000-4000-8000-000000000001",media="cam1",detector="face",device_id="0",hardware="gpu"} 15
+process_uptime_seconds 3600
+`}
+	client := createTestClient(t, rt)
+
+	metrics, err := client.MetricsGetParsed(ctx)
+	if err != nil {
+		t.Fatalf("MetricsGetParsed failed: %v", err)
+	}
+	if got := metrics.Labels.ServerID(); got == nil || *got != "6d1a2e7c-0000-4000-8000-000000000001" {
+		t.Errorf("Expected server_id label, got %v", got)
+	}
+	if len(metrics.Streams) != 1 || len(metrics.Workers) != 1 {
+		t.Fatalf("Expected 1 stream and 1 worker row, got %d and %d", len(metrics.Streams), len(metrics.Workers))
+	}
+
+	stream := metrics.Streams[0]
+	if got := stream.Media(); got == nil || *got != "cam1" {
+		t.Errorf("Expected media cam1, got %v", got)
+	}
+	if got := stream.DecodedFramesCount(); got == nil || *got != 100 {
+		t.Errorf("Expected decoded_frames_count 100, got %v", got)
+	}
+	if got := stream.ProcessingTime(); got == nil || *got != 15 {
+		t.Errorf("Expected processing_time 15, got %v", got)
+	}
+	if got := stream.DeviceID(); got == nil || *got != 0 {
+		t.Errorf("Expected device_id 0, got %v", got)
+	}
+
+	worker := metrics.Workers[0]
+	if got := worker.Media(); got == nil || *got != visioninference.MediaAll {
+		t.Errorf("Expected media all, got %v", got)
+	}
+	if got := worker.DecodedFramesCount(); got == nil || *got != 250 {
+		t.Errorf("Expected decoded_frames_count 250, got %v", got)
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"iter"
 	"net/http"
 	"net/url"
@@ -16,6 +17,7 @@ import (
 	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
 	"github.com/flussonic/go-flussonic/internal/cursors"
+	"github.com/flussonic/go-flussonic/internal/urlpath"
 	model "github.com/flussonic/go-flussonic/watcher-admin/model"
 )
 
@@ -39,7 +41,7 @@ type WatcherAdmin interface {
 	CameraAuthGet(ctx context.Context, name string) (model.Auth, error)
 	// CameraAuthSave Save auth for camera
 	// Create or update a auth for camera by its name.
-	CameraAuthSave(ctx context.Context, name string) (model.Auth, error)
+	CameraAuthSave(ctx context.Context, name string, body model.Auth) (model.Auth, error)
 	// CameraConfigGet Get one stream camera config
 	// This method allows to fetch a single stream camera config info.
 	CameraConfigGet(ctx context.Context, name string) (model.CameraConfig, error)
@@ -52,6 +54,10 @@ type WatcherAdmin interface {
 	// ClusterStatsGet Get current health of cluster
 	// This method allows you to fetch cluster's health
 	ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuery) (model.ClusterHealthStats, error)
+	// ClusterStatsGetRaw Get cluster stats as raw bytes
+	// Returns the response body in the format selected by query.Format.
+	// The caller must close the returned body.
+	ClusterStatsGetRaw(ctx context.Context, query *ClusterStatsGetQuery) (io.ReadCloser, error)
 	// DeleteOrganizationPreset Delete preset from organization
 	// This method allows to remove a specific preset from organization.
 	DeleteOrganizationPreset(ctx context.Context, organization_id string, preset_id string) error
@@ -819,7 +825,8 @@ type BaseClientFactory func(cfg *config.Config, apiURL string, authKey authoriza
 
 // defaultBaseClientFactory creates a default base client instance.
 func defaultBaseClientFactory(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
-	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry,
+		append(baseclient.ConfigOptions(cfg), baseclient.WithProduct("watcher-admin"))...)
 }
 
 // Client is the concrete implementation of the WatcherAdmin interface.
@@ -860,7 +867,15 @@ func NewWithBaseFactory(cfg *config.Config, baseFactory BaseClientFactory) (Watc
 		cfg.Originator = config.DefaultOriginator
 	}
 
-	base := baseFactory(cfg, apiURL, authKey)
+	httpClient, err := cfg.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+	// The caller's config is not changed, so it still builds a client from its settings when reused
+	withClient := *cfg
+	withClient.HTTPClient = httpClient
+
+	base := baseFactory(&withClient, apiURL, authKey)
 
 	return &Client{
 		base:   base,
@@ -921,6 +936,41 @@ func (c *Client) doDelete(ctx context.Context, path string) error {
 	return c.base.Request(ctx, request, nil)
 }
 
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, query interface{ ToQueryString() (string, error) }) (string, error) {
+	queryString, err := query.ToQueryString()
+	if err != nil {
+		return "", fmt.Errorf("failed to build query string: %w", err)
+	}
+	if queryString != "" {
+		path += "?" + queryString
+	}
+	return path, nil
+}
+
+// doStream performs a GET request with query parameters and returns the raw response body.
+// The caller must close the returned body.
+func (c *Client) doStream(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, accept string) (io.ReadCloser, error) {
+	if query != nil {
+		var err error
+		if path, err = withQuery(path, query); err != nil {
+			return nil, err
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	response, err := c.base.RequestStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
 // doList performs a GET request with query parameters and unmarshals the response into result.
 func (c *Client) doList(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, result any) error {
 	if query == nil {
@@ -950,6 +1000,7 @@ func (c *Client) doList(ctx context.Context, path string, query interface{ ToQue
 // AdminStreamsList List streams for admin
 // The key difference between this method and streams_list is the additional parameters. These additional parameters are available only for admin (e.g. 'required_zones' and 'preferred_zones').  This API method is one of the most important in whole API, because it gives the list of all streams.  `streams_list` in Watcher Admin API: * can list all streams within the Watcher. * admin is not allowed to watch content from any cameras for security reasons.  `streams_list` in Watcher Client API: * return streams from organizations where you're member * provives a playback token that allows play video from the camera.
 func (c *Client) AdminStreamsList(ctx context.Context, query *AdminStreamsListQuery) (model.StreamsList, error) {
+	ctx = baseclient.WithOperation(ctx, "AdminStreamsList")
 	path := "/watcher/admin-api/v3/streams"
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -966,7 +1017,8 @@ func (c *Client) AdminStreamsListIterator(ctx context.Context, query *AdminStrea
 // AgentGet Get one Agent
 // The method allows you to fetch a single Agent.  The returned data are the same as for `agents_list` operation.
 func (c *Client) AgentGet(ctx context.Context, id string) (model.WatcherAgentConfig, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/agents/%s", id)
+	ctx = baseclient.WithOperation(ctx, "AgentGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/agents/%s", urlpath.Segment(id))
 	result := &model.WatcherAgentConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -977,6 +1029,7 @@ func (c *Client) AgentGet(ctx context.Context, id string) (model.WatcherAgentCon
 // AgentsList List Agents
 // The list of configured and provisioned Agents, i.e. Agents that connected to your server at least once.
 func (c *Client) AgentsList(ctx context.Context, query *AgentsListQuery) (model.AgentsList, error) {
+	ctx = baseclient.WithOperation(ctx, "AgentsList")
 	path := "/watcher/admin-api/v3/agents"
 	result := &model.AgentsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -993,7 +1046,8 @@ func (c *Client) AgentsListIterator(ctx context.Context, query *AgentsListQuery)
 // CameraAuthGet Get auth for camera
 // Create or update a auth for camera by its name.
 func (c *Client) CameraAuthGet(ctx context.Context, name string) (model.Auth, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/auth", name)
+	ctx = baseclient.WithOperation(ctx, "CameraAuthGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/auth", urlpath.Segment(name))
 	result := &model.AuthImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1003,10 +1057,11 @@ func (c *Client) CameraAuthGet(ctx context.Context, name string) (model.Auth, er
 
 // CameraAuthSave Save auth for camera
 // Create or update a auth for camera by its name.
-func (c *Client) CameraAuthSave(ctx context.Context, name string) (model.Auth, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/auth", name)
+func (c *Client) CameraAuthSave(ctx context.Context, name string, body model.Auth) (model.Auth, error) {
+	ctx = baseclient.WithOperation(ctx, "CameraAuthSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/auth", urlpath.Segment(name))
 	result := &model.AuthImpl{}
-	if err := c.doPut(ctx, path, nil, result); err != nil {
+	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -1015,7 +1070,8 @@ func (c *Client) CameraAuthSave(ctx context.Context, name string) (model.Auth, e
 // CameraConfigGet Get one stream camera config
 // This method allows to fetch a single stream camera config info.
 func (c *Client) CameraConfigGet(ctx context.Context, name string) (model.CameraConfig, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/camera_config", name)
+	ctx = baseclient.WithOperation(ctx, "CameraConfigGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/camera_config", urlpath.Segment(name))
 	result := &model.CameraConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1026,7 +1082,8 @@ func (c *Client) CameraConfigGet(ctx context.Context, name string) (model.Camera
 // CameraConfigSave Save stream camera config
 // Create or update a stream config by its name.
 func (c *Client) CameraConfigSave(ctx context.Context, name string, body model.CameraConfig) (model.CameraConfig, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/camera_config", name)
+	ctx = baseclient.WithOperation(ctx, "CameraConfigSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/camera_config", urlpath.Segment(name))
 	result := &model.CameraConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1037,7 +1094,8 @@ func (c *Client) CameraConfigSave(ctx context.Context, name string, body model.C
 // CameraReboot Reboot camera
 // Reboot camera
 func (c *Client) CameraReboot(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/reboot", name)
+	ctx = baseclient.WithOperation(ctx, "CameraReboot")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/reboot", urlpath.Segment(name))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1047,6 +1105,7 @@ func (c *Client) CameraReboot(ctx context.Context, name string) error {
 // ClusterStatsGet Get current health of cluster
 // This method allows you to fetch cluster's health
 func (c *Client) ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuery) (model.ClusterHealthStats, error) {
+	ctx = baseclient.WithOperation(ctx, "ClusterStatsGet")
 	path := "/watcher/admin-api/v3/cluster/stats"
 	result := &model.ClusterHealthStatsImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1055,10 +1114,20 @@ func (c *Client) ClusterStatsGet(ctx context.Context, query *ClusterStatsGetQuer
 	return result, nil
 }
 
+// ClusterStatsGetRaw Get cluster stats as raw bytes
+// Returns the response body in the format selected by query.Format.
+// The caller must close the returned body.
+func (c *Client) ClusterStatsGetRaw(ctx context.Context, query *ClusterStatsGetQuery) (io.ReadCloser, error) {
+	ctx = baseclient.WithOperation(ctx, "ClusterStatsGetRaw")
+	path := "/watcher/admin-api/v3/cluster/stats"
+	return c.doStream(ctx, path, query, "")
+}
+
 // DeleteOrganizationPreset Delete preset from organization
 // This method allows to remove a specific preset from organization.
 func (c *Client) DeleteOrganizationPreset(ctx context.Context, organization_id string, preset_id string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/organizations/%s/presets/%s", organization_id, preset_id)
+	ctx = baseclient.WithOperation(ctx, "DeleteOrganizationPreset")
+	path := fmt.Sprintf("/watcher/admin-api/v3/organizations/%s/presets/%s", urlpath.Segment(organization_id), urlpath.Segment(preset_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1068,6 +1137,7 @@ func (c *Client) DeleteOrganizationPreset(ctx context.Context, organization_id s
 // DomainConfigGet Get domain settings
 // The method allows you to get the domain settings
 func (c *Client) DomainConfigGet(ctx context.Context) (model.DomainConfig, error) {
+	ctx = baseclient.WithOperation(ctx, "DomainConfigGet")
 	path := "/watcher/admin-api/v3/domain_config"
 	result := &model.DomainConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1079,6 +1149,7 @@ func (c *Client) DomainConfigGet(ctx context.Context) (model.DomainConfig, error
 // DomainConfigSave Save domain settings
 // The method allows you to save the domain settings
 func (c *Client) DomainConfigSave(ctx context.Context, body model.DomainConfig) error {
+	ctx = baseclient.WithOperation(ctx, "DomainConfigSave")
 	path := "/watcher/admin-api/v3/domain_config"
 	if err := c.doPut(ctx, path, body, nil); err != nil {
 		return err
@@ -1089,6 +1160,7 @@ func (c *Client) DomainConfigSave(ctx context.Context, body model.DomainConfig)
 // EventsList Get events of Watcher
 // This method allows to get the batch of events. Currently this method is not implemented yet. However, its schema can be used for understanding Watcher logging.
 func (c *Client) EventsList(ctx context.Context, query *EventsListQuery) (model.EventsList, error) {
+	ctx = baseclient.WithOperation(ctx, "EventsList")
 	path := "/watcher/admin-api/v3/events"
 	result := &model.EventsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1105,6 +1177,7 @@ func (c *Client) EventsListIterator(ctx context.Context, query *EventsListQuery)
 // LetsencryptIssue Issue letsencrypt certificate
 // Issue letsencrypt certificate
 func (c *Client) LetsencryptIssue(ctx context.Context, body model.LetsencryptRequest) (model.TlsCertificate, error) {
+	ctx = baseclient.WithOperation(ctx, "LetsencryptIssue")
 	path := "/watcher/admin-api/v3/tls/letsencrypt"
 	result := &model.TlsCertificateImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1116,6 +1189,7 @@ func (c *Client) LetsencryptIssue(ctx context.Context, body model.LetsencryptReq
 // LivenessProbe Liveness probe
 // K8s liveness probe.
 func (c *Client) LivenessProbe(ctx context.Context) (model.ServerStatsWhoami, error) {
+	ctx = baseclient.WithOperation(ctx, "LivenessProbe")
 	path := "/watcher/admin-api/v3/monitoring/liveness"
 	result := &model.ServerStatsWhoamiImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1127,6 +1201,7 @@ func (c *Client) LivenessProbe(ctx context.Context) (model.ServerStatsWhoami, er
 // LoginCreate Get an auth token
 // The method creates a new temporary auth-token (JWT). The JTW token used as Bearer token in requests other than `/login`.
 func (c *Client) LoginCreate(ctx context.Context) (model.LoginInfoAdditional, error) {
+	ctx = baseclient.WithOperation(ctx, "LoginCreate")
 	path := "/watcher/admin-api/v3/login"
 	result := &model.LoginInfoAdditionalImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
@@ -1138,7 +1213,8 @@ func (c *Client) LoginCreate(ctx context.Context) (model.LoginInfoAdditional, er
 // OrganizationPresetSave Update organizations preset
 // Save organization preset by its id or adds new preset to organization.
 func (c *Client) OrganizationPresetSave(ctx context.Context, organization_id string, body model.OrganizationPreset) (model.Preset, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/organizations/%s/presets", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationPresetSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/organizations/%s/presets", urlpath.Segment(organization_id))
 	result := &model.PresetImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1149,7 +1225,8 @@ func (c *Client) OrganizationPresetSave(ctx context.Context, organization_id str
 // PresetGet Get one preset
 // This method is used to get info about preset by its id.
 func (c *Client) PresetGet(ctx context.Context, id string) (model.Preset, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/presets/%s", id)
+	ctx = baseclient.WithOperation(ctx, "PresetGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/presets/%s", urlpath.Segment(id))
 	result := &model.PresetImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1160,7 +1237,8 @@ func (c *Client) PresetGet(ctx context.Context, id string) (model.Preset, error)
 // PresetSave Update preset
 // This method will update the existing preset with the specified id.
 func (c *Client) PresetSave(ctx context.Context, id string, body model.Preset) (model.Preset, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/presets/%s", id)
+	ctx = baseclient.WithOperation(ctx, "PresetSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/presets/%s", urlpath.Segment(id))
 	result := &model.PresetImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1171,6 +1249,7 @@ func (c *Client) PresetSave(ctx context.Context, id string, body model.Preset) (
 // PresetsCreate Create preset
 // This method will create a new preset.
 func (c *Client) PresetsCreate(ctx context.Context, body model.Preset) (model.Preset, error) {
+	ctx = baseclient.WithOperation(ctx, "PresetsCreate")
 	path := "/watcher/admin-api/v3/presets"
 	result := &model.PresetImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1182,6 +1261,7 @@ func (c *Client) PresetsCreate(ctx context.Context, body model.Preset) (model.Pr
 // PresetsList List presets
 // The presets in Watcher is a set of DVR and analytics parameters that you can use as a template when creating and configuring cameras. When you select a preset in the camera settings, the parameters from the preset are populated to the camera settings. A set of presets on the camera is defined by the set of presets selected for the camera's Organization. https://flussonic.com/doc/manage-presets-in-watcher-ui/  This method is used to get info about presets. If the `organization_id` is transferred this method will return presets only allowed in organization with that Id.
 func (c *Client) PresetsList(ctx context.Context, query *PresetsListQuery) (model.PresetsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PresetsList")
 	path := "/watcher/admin-api/v3/presets"
 	result := &model.PresetsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1198,7 +1278,11 @@ func (c *Client) PresetsListIterator(ctx context.Context, query *PresetsListQuer
 // PreviewLayoutChangeForStreamConfig Dry run layouter for changed stream configuration
 // This method allows you to see how the overall layout will be changed due to changes into stream configuration. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) PreviewLayoutChangeForStreamConfig(ctx context.Context, name string, query *PreviewLayoutChangeForStreamConfigQuery, body model.StreamConfig) (model.CentralStreamLayoutPrediction, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/preview_layout_change", name)
+	ctx = baseclient.WithOperation(ctx, "PreviewLayoutChangeForStreamConfig")
+	path, err := withQuery(fmt.Sprintf("/watcher/admin-api/v3/streams/%s/preview_layout_change", urlpath.Segment(name)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1216,7 +1300,11 @@ func (c *Client) PreviewLayoutChangeForStreamConfigIterator(ctx context.Context,
 // PreviewLayoutChangeForStreamerConfig Dry run layouter for changed streamer configuration
 // This method allows you to see how the overall layout will be changed due to changes into streamer configuration. Note that if [Layouter](https://flussonic.com/doc/api/layouter/) is [disabled](https://flussonic.com/doc/api/central/#tag/config/operation/config_save%7Cbody%7Clayouter_enabled), this method will return an error.
 func (c *Client) PreviewLayoutChangeForStreamerConfig(ctx context.Context, hostname string, query *PreviewLayoutChangeForStreamerConfigQuery, body model.StreamerLayoutPrediction) (model.CentralStreamerLayoutPrediction, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s/preview_layout_change", hostname)
+	ctx = baseclient.WithOperation(ctx, "PreviewLayoutChangeForStreamerConfig")
+	path, err := withQuery(fmt.Sprintf("/watcher/admin-api/v3/streamers/%s/preview_layout_change", urlpath.Segment(hostname)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.CentralStreamerLayoutPredictionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1234,6 +1322,7 @@ func (c *Client) PreviewLayoutChangeForStreamerConfigIterator(ctx context.Contex
 // ReadinessProbe Readiness probe
 // K8s readiness probe.
 func (c *Client) ReadinessProbe(ctx context.Context) (model.ServerStatsWhoami, error) {
+	ctx = baseclient.WithOperation(ctx, "ReadinessProbe")
 	path := "/watcher/admin-api/v3/monitoring/readiness"
 	result := &model.ServerStatsWhoamiImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1245,6 +1334,7 @@ func (c *Client) ReadinessProbe(ctx context.Context) (model.ServerStatsWhoami, e
 // SharedTokenCreate Create Shared token
 // Method to create shared token
 func (c *Client) SharedTokenCreate(ctx context.Context, body model.SharedToken) (model.SharedToken, error) {
+	ctx = baseclient.WithOperation(ctx, "SharedTokenCreate")
 	path := "/watcher/admin-api/v3/shares"
 	result := &model.SharedTokenImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1256,7 +1346,8 @@ func (c *Client) SharedTokenCreate(ctx context.Context, body model.SharedToken)
 // SharedTokenDelete Delete Shared token
 // This method allows to delete a single shared token.
 func (c *Client) SharedTokenDelete(ctx context.Context, key string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/shares/%s", key)
+	ctx = baseclient.WithOperation(ctx, "SharedTokenDelete")
+	path := fmt.Sprintf("/watcher/admin-api/v3/shares/%s", urlpath.Segment(key))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1266,6 +1357,7 @@ func (c *Client) SharedTokenDelete(ctx context.Context, key string) error {
 // SharedTokensList List Shared tokens
 // Method allows to fetch configured Shared tokens
 func (c *Client) SharedTokensList(ctx context.Context, query *SharedTokensListQuery) (model.SharedTokensList, error) {
+	ctx = baseclient.WithOperation(ctx, "SharedTokensList")
 	path := "/watcher/admin-api/v3/shares"
 	result := &model.SharedTokensListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1282,7 +1374,11 @@ func (c *Client) SharedTokensListIterator(ctx context.Context, query *SharedToke
 // StreamDelete Delete the stream
 // This method allows to delete a single stream. You can delete `config_override` by this API call if you want to remove all runtime overrides.  If you do not specify any `part` parameter, this stream will be deleted from `config_on_disk` and if it was `named_by: config`, then it will immediately stop.
 func (c *Client) StreamDelete(ctx context.Context, name string, query *StreamDeleteQuery) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamDelete")
+	path, err := withQuery(fmt.Sprintf("/watcher/admin-api/v3/streams/%s", urlpath.Segment(name)), query)
+	if err != nil {
+		return err
+	}
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1292,7 +1388,8 @@ func (c *Client) StreamDelete(ctx context.Context, name string, query *StreamDel
 // StreamFirmwareUpdate Update camera firmware
 // This method allows you to update camera firmware
 func (c *Client) StreamFirmwareUpdate(ctx context.Context, name string, body model.FirmwareUpdate) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/firmware_update", name)
+	ctx = baseclient.WithOperation(ctx, "StreamFirmwareUpdate")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/firmware_update", urlpath.Segment(name))
 	if err := c.doPut(ctx, path, body, nil); err != nil {
 		return err
 	}
@@ -1302,7 +1399,8 @@ func (c *Client) StreamFirmwareUpdate(ctx context.Context, name string, body mod
 // StreamGet Get one stream
 // This method allows to fetch a single stream. Refer to the `streams_list` operation to find out what data will be returned in this method.
 func (c *Client) StreamGet(ctx context.Context, name string) (model.StreamConfig, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s", urlpath.Segment(name))
 	result := &model.StreamConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1313,7 +1411,8 @@ func (c *Client) StreamGet(ctx context.Context, name string) (model.StreamConfig
 // StreamLayoutsGet Get layouts of stream
 // This method allows you to fetch stream layouts list
 func (c *Client) StreamLayoutsGet(ctx context.Context, name string, query *StreamLayoutsGetQuery) (model.CentralStreamLayouts, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/layouts", name)
+	ctx = baseclient.WithOperation(ctx, "StreamLayoutsGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s/layouts", urlpath.Segment(name))
 	result := &model.CentralStreamLayoutsImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1331,7 +1430,11 @@ func (c *Client) StreamLayoutsGetIterator(ctx context.Context, name string, quer
 // StreamSave Save stream
 // Create or update a stream by its name. If the stream doesn't exists in the disk config, it will be created.  If you try to update a stream that is started from a template by a user request having `named_by=user`, a new stream will be created in the disk config.  If you pass only a partial stream configuration, this field will be updated, not the whole stream.  To create a new stream the property `name` is required.  Pass the `"$reset": true` option to replace the stream configuration with the provided one.
 func (c *Client) StreamSave(ctx context.Context, name string, query *StreamSaveQuery, body model.StreamConfig) (model.StreamConfig, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamSave")
+	path, err := withQuery(fmt.Sprintf("/watcher/admin-api/v3/streams/%s", urlpath.Segment(name)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.StreamConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1342,7 +1445,8 @@ func (c *Client) StreamSave(ctx context.Context, name string, query *StreamSaveQ
 // StreamerDelete Delete the streamer
 // Delete the streamer by its hostname.
 func (c *Client) StreamerDelete(ctx context.Context, hostname string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerDelete")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", urlpath.Segment(hostname))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1352,7 +1456,8 @@ func (c *Client) StreamerDelete(ctx context.Context, hostname string) error {
 // StreamerGet Get the streamer
 // This method allows you to fetch the main parameters and current metrics of a single streamer
 func (c *Client) StreamerGet(ctx context.Context, hostname string) (model.Streamer, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", urlpath.Segment(hostname))
 	result := &model.StreamerImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1363,7 +1468,11 @@ func (c *Client) StreamerGet(ctx context.Context, hostname string) (model.Stream
 // StreamerSave Save the streamer
 // This method will either create a new streamer (if there is no streamer with the hostname specified in the request), or update the existing streamer having the specified hostname.
 func (c *Client) StreamerSave(ctx context.Context, hostname string, query *StreamerSaveQuery, body model.Streamer) (model.Streamer, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", hostname)
+	ctx = baseclient.WithOperation(ctx, "StreamerSave")
+	path, err := withQuery(fmt.Sprintf("/watcher/admin-api/v3/streamers/%s", urlpath.Segment(hostname)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.StreamerImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1374,6 +1483,7 @@ func (c *Client) StreamerSave(ctx context.Context, hostname string, query *Strea
 // StreamersList List streamers
 // Returns the list of all streamers with main parameters and current metric values.
 func (c *Client) StreamersList(ctx context.Context, query *StreamersListQuery) (model.StreamersList, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamersList")
 	path := "/watcher/admin-api/v3/streamers"
 	result := &model.StreamersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1390,6 +1500,7 @@ func (c *Client) StreamersListIterator(ctx context.Context, query *StreamersList
 // StreamsMultiedit Save streams settings
 // Create or update settings for each stream. This operation does everything or nothing. If at least one stream has an invalid configuration or can't be processed, the request will fail.
 func (c *Client) StreamsMultiedit(ctx context.Context, body model.StreamsMultieditConfig) error {
+	ctx = baseclient.WithOperation(ctx, "StreamsMultiedit")
 	path := "/watcher/admin-api/v3/streams/multiedit"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1400,6 +1511,7 @@ func (c *Client) StreamsMultiedit(ctx context.Context, body model.StreamsMultied
 // StreamsZonesSettings Save streams with zone settings
 // Create or update zone settings for each stream. This operation does everything or nothing. If at least one stream has an invalid configuration or can't be processed, the request will fail.
 func (c *Client) StreamsZonesSettings(ctx context.Context, body model.StreamsZoneSettings) error {
+	ctx = baseclient.WithOperation(ctx, "StreamsZonesSettings")
 	path := "/watcher/admin-api/v3/streams/zones"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1410,6 +1522,7 @@ func (c *Client) StreamsZonesSettings(ctx context.Context, body model.StreamsZon
 // SystemConfigGet Get system settings
 // The method allows you to get the system settings
 func (c *Client) SystemConfigGet(ctx context.Context) (model.SystemConfig, error) {
+	ctx = baseclient.WithOperation(ctx, "SystemConfigGet")
 	path := "/watcher/admin-api/v3/system_config"
 	result := &model.SystemConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1421,6 +1534,7 @@ func (c *Client) SystemConfigGet(ctx context.Context) (model.SystemConfig, error
 // TlsCertificateGet Get tls certificate
 // Get current tls certificate
 func (c *Client) TlsCertificateGet(ctx context.Context) (model.TlsCertificate, error) {
+	ctx = baseclient.WithOperation(ctx, "TlsCertificateGet")
 	path := "/watcher/admin-api/v3/tls/letsencrypt"
 	result := &model.TlsCertificateImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1432,6 +1546,7 @@ func (c *Client) TlsCertificateGet(ctx context.Context) (model.TlsCertificate, e
 // UserCreate Create user
 // This method allows you to create a user
 func (c *Client) UserCreate(ctx context.Context, body any) (model.User, error) {
+	ctx = baseclient.WithOperation(ctx, "UserCreate")
 	path := "/watcher/admin-api/v3/users"
 	result := &model.UserImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1443,7 +1558,8 @@ func (c *Client) UserCreate(ctx context.Context, body any) (model.User, error) {
 // UserDelete Delete a user
 // This method allows you to delete a user
 func (c *Client) UserDelete(ctx context.Context, user_id string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserDelete")
+	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", urlpath.Segment(user_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1453,7 +1569,8 @@ func (c *Client) UserDelete(ctx context.Context, user_id string) error {
 // UserGet Get a user
 // This method allows you to fetch the user by its identifier
 func (c *Client) UserGet(ctx context.Context, user_id string) (model.User, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", urlpath.Segment(user_id))
 	result := &model.UserImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1464,7 +1581,8 @@ func (c *Client) UserGet(ctx context.Context, user_id string) (model.User, error
 // UserSave Save user
 // This method allows you to update user data
 func (c *Client) UserSave(ctx context.Context, user_id string, body model.User) (model.User, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s", urlpath.Segment(user_id))
 	result := &model.UserImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1475,7 +1593,8 @@ func (c *Client) UserSave(ctx context.Context, user_id string, body model.User)
 // UsersApikeyCreate Create new API key for user
 // The API key is used for authentication in API requests, allowing access to protected resources without using a password.
 func (c *Client) UsersApikeyCreate(ctx context.Context, user_id string) (model.Apikey, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s/apikey", user_id)
+	ctx = baseclient.WithOperation(ctx, "UsersApikeyCreate")
+	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s/apikey", urlpath.Segment(user_id))
 	result := &model.ApikeyImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
 		return nil, err
@@ -1486,7 +1605,8 @@ func (c *Client) UsersApikeyCreate(ctx context.Context, user_id string) (model.A
 // UsersApikeyGet Get user's API key
 // The API key is used for authentication in API requests, allowing access to protected resources without using a password.
 func (c *Client) UsersApikeyGet(ctx context.Context, user_id string) (model.Apikey, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s/apikey", user_id)
+	ctx = baseclient.WithOperation(ctx, "UsersApikeyGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/users/%s/apikey", urlpath.Segment(user_id))
 	result := &model.ApikeyImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1497,6 +1617,7 @@ func (c *Client) UsersApikeyGet(ctx context.Context, user_id string) (model.Apik
 // UsersList List of users
 // List of available users
 func (c *Client) UsersList(ctx context.Context, query *UsersListQuery) (model.Users, error) {
+	ctx = baseclient.WithOperation(ctx, "UsersList")
 	path := "/watcher/admin-api/v3/users"
 	result := &model.UsersImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1513,6 +1634,7 @@ func (c *Client) UsersListIterator(ctx context.Context, query *UsersListQuery) i
 // WatcherProvision The operator identifier creation
 // This method allows you to create the operator identifier
 func (c *Client) WatcherProvision(ctx context.Context) (model.WatcherStatus, error) {
+	ctx = baseclient.WithOperation(ctx, "WatcherProvision")
 	path := "/watcher/admin-api/v3/peeklio/create_operator_id"
 	result := &model.WatcherStatusImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
@@ -1524,6 +1646,7 @@ func (c *Client) WatcherProvision(ctx context.Context) (model.WatcherStatus, err
 // WatcherStatusGet Get the Watcher status
 // Get the Watcher status on the agent server.
 func (c *Client) WatcherStatusGet(ctx context.Context) (model.WatcherStatus, error) {
+	ctx = baseclient.WithOperation(ctx, "WatcherStatusGet")
 	path := "/watcher/admin-api/v3/peeklio/check_watcher_status"
 	result := &model.WatcherStatusImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -1535,7 +1658,8 @@ func (c *Client) WatcherStatusGet(ctx context.Context) (model.WatcherStatus, err
 // ZoneDelete Delete the zone
 // Delete the zone by its name.
 func (c *Client) ZoneDelete(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", name)
+	ctx = baseclient.WithOperation(ctx, "ZoneDelete")
+	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", urlpath.Segment(name))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1545,7 +1669,8 @@ func (c *Client) ZoneDelete(ctx context.Context, name string) error {
 // ZoneGet Get the zone
 // This method allows you to fetch the main parameters and current metrics of a single zone
 func (c *Client) ZoneGet(ctx context.Context, name string) (model.Zone, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", name)
+	ctx = baseclient.WithOperation(ctx, "ZoneGet")
+	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", urlpath.Segment(name))
 	result := &model.ZoneImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1556,7 +1681,8 @@ func (c *Client) ZoneGet(ctx context.Context, name string) (model.Zone, error) {
 // ZoneSave Save the zone
 // This method will either create a new zone (if there is no zone with the name specified in the request), or update the existing zone having the specified name.
 func (c *Client) ZoneSave(ctx context.Context, name string, body model.Zone) (model.Zone, error) {
-	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", name)
+	ctx = baseclient.WithOperation(ctx, "ZoneSave")
+	path := fmt.Sprintf("/watcher/admin-api/v3/zones/%s", urlpath.Segment(name))
 	result := &model.ZoneImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1567,6 +1693,7 @@ func (c *Client) ZoneSave(ctx context.Context, name string, body model.Zone) (mo
 // ZonesList List zones
 // Returns the list of all zones with main parameters and current metric values.
 func (c *Client) ZonesList(ctx context.Context, query *ZonesListQuery) (model.ZonesList, error) {
+	ctx = baseclient.WithOperation(ctx, "ZonesList")
 	path := "/watcher/admin-api/v3/zones"
 	result := &model.ZonesListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -203,12 +203,12 @@ func TestClient_CameraAuthSave(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.CameraAuthSave(ctx, "test-name")
+	_, err := client.CameraAuthSave(ctx, "test-name", &model.AuthImpl{})
 	if err != nil {
 		t.Fatalf("CameraAuthSave failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.CameraAuthSave(ctx, "test-name")
+	result, err := client.CameraAuthSave(ctx, "test-name", &model.AuthImpl{})
 	if err != nil {
 		t.Fatalf("CameraAuthSave failed: %v", err)
 	}
@@ -2072,6 +2072,206 @@ func TestClient_ZonesList(t *testing.T) {
 	verifyMarshalUnmarshal(t, result, expectedJSON)
 }
 
+func TestClient_StreamSaveSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &watcheradmin.StreamSaveQuery{Part: "config_override"}
+	if _, err := client.StreamSave(ctx, "test-name", query, &model.StreamConfigImpl{}); err != nil {
+		t.Fatalf("StreamSave failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("part"); got != "config_override" {
+		t.Errorf("Expected query part=config_override, got %q", got)
+	}
+}
+
+func TestClient_StreamDeleteSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &watcheradmin.StreamDeleteQuery{Part: "config_override"}
+	if err := client.StreamDelete(ctx, "test-name", query); err != nil {
+		t.Fatalf("StreamDelete failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("part"); got != "config_override" {
+		t.Errorf("Expected query part=config_override, got %q", got)
+	}
+}
+
+func TestClient_CameraAuthSaveSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	body := model.NewAuth()
+	body.SetLogin("admin")
+	body.SetPassword("secret")
+	if _, err := client.CameraAuthSave(ctx, "test-name", body); err != nil {
+		t.Fatalf("CameraAuthSave failed: %v", err)
+	}
+	verifyRequestBody(t, rt.body, `{"login":"admin","password":"secret"}`)
+}
+
+func TestClient_ClusterStatsGetRaw(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: "name,alive\nfoo,true\n"}
+	client := createTestClient(t, rt)
+
+	body, err := client.ClusterStatsGetRaw(ctx, &watcheradmin.ClusterStatsGetQuery{Format: "csv"})
+	if err != nil {
+		t.Fatalf("ClusterStatsGetRaw failed: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(data) != "name,alive\nfoo,true\n" {
+		t.Errorf("Expected raw body, got %q", data)
+	}
+	if got := rt.request.URL.Query().Get("format"); got != "csv" {
+		t.Errorf("Expected query format=csv, got %q", got)
+	}
+}
+
+func TestClient_StreamGetEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for name, expected := range map[string]string{
+		"mylive/bunny":      "/watcher/admin-api/v3/streams/mylive%2Fbunny",
+		"my stream":         "/watcher/admin-api/v3/streams/my%20stream",
+		"камера":            "/watcher/admin-api/v3/streams/%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0",
+		"../../config":      "/watcher/admin-api/v3/streams/..%2F..%2Fconfig",
+		"..":                "/watcher/admin-api/v3/streams/%2E%2E",
+		"name?format=csv#x": "/watcher/admin-api/v3/streams/name%3Fformat=csv%23x",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.StreamGet(ctx, name); err != nil {
+			t.Fatalf("StreamGet(%q) failed: %v", name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("StreamGet(%q): expected path %s, got %s", name, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("StreamGet(%q): unexpected query %q", name, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_QueryMethodsEscapeName(t *testing.T) {
+	ctx := context.Background()
+	name := "../live/cam?x=1#y"
+	for _, tt := range []struct {
+		method string
+		path   string
+		query  string
+		call   func(client watcheradmin.WatcherAdmin) error
+	}{
+		{
+			"PreviewLayoutChangeForStreamConfig",
+			"/watcher/admin-api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y/preview_layout_change",
+			"limit=10",
+			func(client watcheradmin.WatcherAdmin) error {
+				_, err := client.PreviewLayoutChangeForStreamConfig(ctx, name, &watcheradmin.PreviewLayoutChangeForStreamConfigQuery{Limit: 10}, &model.StreamConfigImpl{})
+				return err
+			},
+		},
+		{
+			"PreviewLayoutChangeForStreamerConfig",
+			"/watcher/admin-api/v3/streamers/..%2Flive%2Fcam%3Fx=1%23y/preview_layout_change",
+			"limit=10",
+			func(client watcheradmin.WatcherAdmin) error {
+				_, err := client.PreviewLayoutChangeForStreamerConfig(ctx, name, &watcheradmin.PreviewLayoutChangeForStreamerConfigQuery{Limit: 10}, &model.StreamerLayoutPredictionImpl{})
+				return err
+			},
+		},
+		{
+			"StreamDelete",
+			"/watcher/admin-api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y",
+			"part=dvr",
+			func(client watcheradmin.WatcherAdmin) error {
+				return client.StreamDelete(ctx, name, &watcheradmin.StreamDeleteQuery{Part: "dvr"})
+			},
+		},
+		{
+			"StreamSave",
+			"/watcher/admin-api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y",
+			"part=dvr",
+			func(client watcheradmin.WatcherAdmin) error {
+				_, err := client.StreamSave(ctx, name, &watcheradmin.StreamSaveQuery{Part: "dvr"}, &model.StreamConfigImpl{})
+				return err
+			},
+		},
+		{
+			"StreamerSave",
+			"/watcher/admin-api/v3/streamers/..%2Flive%2Fcam%3Fx=1%23y",
+			"checks_enable=true",
+			func(client watcheradmin.WatcherAdmin) error {
+				_, err := client.StreamerSave(ctx, name, &watcheradmin.StreamerSaveQuery{ChecksEnable: true}, &model.StreamerImpl{})
+				return err
+			},
+		},
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if err := tt.call(client); err != nil {
+			t.Fatalf("%s(%q) failed: %v", tt.method, name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != tt.path {
+			t.Errorf("%s(%q): expected path %s, got %s", tt.method, name, tt.path, got)
+		}
+		if rt.request.URL.RawQuery != tt.query {
+			t.Errorf("%s(%q): expected query %q, got %q", tt.method, name, tt.query, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
+// verifyRequestBody checks that every field of expectedJSON is present in the sent body
+func verifyRequestBody(t *testing.T, body []byte, expectedJSON string) {
+	var expected, actual map[string]any
+	if err := json.Unmarshal([]byte(expectedJSON), &expected); err != nil {
+		t.Fatalf("Failed to unmarshal expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(body, &actual); err != nil {
+		t.Fatalf("Failed to unmarshal request body %q: %v", string(body), err)
+	}
+	for key, value := range expected {
+		expectedValue, _ := json.Marshal(value)
+		actualValue, _ := json.Marshal(actual[key])
+		if !bytes.Equal(expectedValue, actualValue) {
+			t.Errorf("Body key %s: expected %s, got %s", key, expectedValue, actualValue)
+		}
+	}
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string
@@ -0,0 +1,40 @@
+package watcherclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flussonic/go-flussonic/authorization"
+)
+
+// LoginAuth returns an AuthKey authorizing requests with a session token obtained
+// with LoginCreate of the login client, which is configured with user credentials.
+// The token is requested on first use and again when the server rejects it.
+func LoginAuth(login WatcherClient) authorization.RefreshableAuthKey {
+	return authorization.Refreshable(func(ctx context.Context) (string, error) {
+		info, err := login.LoginCreate(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to log in: %w", err)
+		}
+		if info.AccessToken() == nil {
+			return "", fmt.Errorf("login response has no access token")
+		}
+		return *info.AccessToken(), nil
+	})
+}
+
+// ApikeyAuth returns an AuthKey authorizing requests with an API key created
+// with UserApikeyCreate of the login client, which is configured with user credentials.
+// The key is created on first use and rotated when the server rejects it.
+func ApikeyAuth(login WatcherClient) authorization.RefreshableAuthKey {
+	return authorization.Refreshable(func(ctx context.Context) (string, error) {
+		apikey, err := login.UserApikeyCreate(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to create apikey: %w", err)
+		}
+		if apikey.Apikey() == nil {
+			return "", fmt.Errorf("apikey response has no apikey")
+		}
+		return *apikey.Apikey(), nil
+	})
+}
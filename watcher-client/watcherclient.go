@@ -16,6 +16,7 @@ import (
 	"github.com/flussonic/go-flussonic/config"
 	"github.com/flussonic/go-flussonic/internal/baseclient"
 	"github.com/flussonic/go-flussonic/internal/cursors"
+	"github.com/flussonic/go-flussonic/internal/urlpath"
 	model "github.com/flussonic/go-flussonic/watcher-client/model"
 )
 
@@ -32,7 +33,7 @@ type WatcherClient interface {
 	CameraAuthGet(ctx context.Context, name string) (model.Auth, error)
 	// CameraAuthSave Save auth for camera
 	// Create or update a auth for camera by its name.
-	CameraAuthSave(ctx context.Context, name string) (model.Auth, error)
+	CameraAuthSave(ctx context.Context, name string, body model.Auth) (model.Auth, error)
 	// CameraConfigGet Get one stream camera config
 	// This method allows to fetch a single stream camera config info.
 	CameraConfigGet(ctx context.Context, name string) (model.CameraConfig, error)
@@ -1187,7 +1188,8 @@ type BaseClientFactory func(cfg *config.Config, apiURL string, authKey authoriza
 
 // defaultBaseClientFactory creates a default base client instance.
 func defaultBaseClientFactory(cfg *config.Config, apiURL string, authKey authorization.AuthKey) baseclient.BaseClient {
-	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry)
+	return baseclient.New(cfg.HTTPClient, apiURL, authKey, cfg.Originator, cfg.Retry,
+		append(baseclient.ConfigOptions(cfg), baseclient.WithProduct("watcher-client"))...)
 }
 
 // Client is the concrete implementation of the WatcherClient interface.
@@ -1228,7 +1230,15 @@ func NewWithBaseFactory(cfg *config.Config, baseFactory BaseClientFactory) (Watc
 		cfg.Originator = config.DefaultOriginator
 	}
 
-	base := baseFactory(cfg, apiURL, authKey)
+	httpClient, err := cfg.BuildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
+	}
+	// The caller's config is not changed, so it still builds a client from its settings when reused
+	withClient := *cfg
+	withClient.HTTPClient = httpClient
+
+	base := baseFactory(&withClient, apiURL, authKey)
 
 	return &Client{
 		base:   base,
@@ -1289,6 +1299,32 @@ func (c *Client) doDelete(ctx context.Context, path string) error {
 	return c.base.Request(ctx, request, nil)
 }
 
+// doDeleteWithBody performs a DELETE request with a JSON body.
+func (c *Client) doDeleteWithBody(ctx context.Context, path string, body any) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.apiURL+path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	return c.base.Request(ctx, request, nil)
+}
+
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, query interface{ ToQueryString() (string, error) }) (string, error) {
+	queryString, err := query.ToQueryString()
+	if err != nil {
+		return "", fmt.Errorf("failed to build query string: %w", err)
+	}
+	if queryString != "" {
+		path += "?" + queryString
+	}
+	return path, nil
+}
+
 // doList performs a GET request with query parameters and unmarshals the response into result.
 func (c *Client) doList(ctx context.Context, path string, query interface{ ToQueryString() (string, error) }, result any) error {
 	if query == nil {
@@ -1318,6 +1354,7 @@ func (c *Client) doList(ctx context.Context, path string, query interface{ ToQue
 // AgentActivationTokenCreate Create activation token
 // This method creates activation token for agent. This token used by mobile app in qr-code for wifi camera with agent for adding camera with agent in watcher.
 func (c *Client) AgentActivationTokenCreate(ctx context.Context, body model.AgentActivationTokenRequest) (model.AgentActivationToken, error) {
+	ctx = baseclient.WithOperation(ctx, "AgentActivationTokenCreate")
 	path := "/watcher/client-api/v3/agent_activation_token"
 	result := &model.AgentActivationTokenImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1329,7 +1366,8 @@ func (c *Client) AgentActivationTokenCreate(ctx context.Context, body model.Agen
 // AgentActivationTokenGet Check activation token provisioned to watcher
 // This method allows you to check that camera with agent was provisioned to watcher. If you got 404 on this method you - token not existing and you must create a new one to add camera with agent. If no camera_name field in response - camera not provisioned yet to watcher. If camera_name field in response - camera provisioned to watcher.
 func (c *Client) AgentActivationTokenGet(ctx context.Context, token string) (model.AgentActivationToken, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/agent_activation_token/%s", token)
+	ctx = baseclient.WithOperation(ctx, "AgentActivationTokenGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/agent_activation_token/%s", urlpath.Segment(token))
 	result := &model.AgentActivationTokenImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1340,7 +1378,8 @@ func (c *Client) AgentActivationTokenGet(ctx context.Context, token string) (mod
 // CameraAuthGet Get auth for camera
 // Create or update a auth for camera by its name.
 func (c *Client) CameraAuthGet(ctx context.Context, name string) (model.Auth, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/auth", name)
+	ctx = baseclient.WithOperation(ctx, "CameraAuthGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/auth", urlpath.Segment(name))
 	result := &model.AuthImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1350,10 +1389,11 @@ func (c *Client) CameraAuthGet(ctx context.Context, name string) (model.Auth, er
 
 // CameraAuthSave Save auth for camera
 // Create or update a auth for camera by its name.
-func (c *Client) CameraAuthSave(ctx context.Context, name string) (model.Auth, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/auth", name)
+func (c *Client) CameraAuthSave(ctx context.Context, name string, body model.Auth) (model.Auth, error) {
+	ctx = baseclient.WithOperation(ctx, "CameraAuthSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/auth", urlpath.Segment(name))
 	result := &model.AuthImpl{}
-	if err := c.doPut(ctx, path, nil, result); err != nil {
+	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -1362,7 +1402,8 @@ func (c *Client) CameraAuthSave(ctx context.Context, name string) (model.Auth, e
 // CameraConfigGet Get one stream camera config
 // This method allows to fetch a single stream camera config info.
 func (c *Client) CameraConfigGet(ctx context.Context, name string) (model.CameraConfig, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/camera_config", name)
+	ctx = baseclient.WithOperation(ctx, "CameraConfigGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/camera_config", urlpath.Segment(name))
 	result := &model.CameraConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1373,7 +1414,8 @@ func (c *Client) CameraConfigGet(ctx context.Context, name string) (model.Camera
 // CameraConfigSave Save stream camera config
 // Create or update a stream config by its name.
 func (c *Client) CameraConfigSave(ctx context.Context, name string, body model.CameraConfig) (model.CameraConfig, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/camera_config", name)
+	ctx = baseclient.WithOperation(ctx, "CameraConfigSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/camera_config", urlpath.Segment(name))
 	result := &model.CameraConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1384,7 +1426,8 @@ func (c *Client) CameraConfigSave(ctx context.Context, name string, body model.C
 // CameraReboot Reboot camera
 // Reboot camera
 func (c *Client) CameraReboot(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/reboot", name)
+	ctx = baseclient.WithOperation(ctx, "CameraReboot")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/reboot", urlpath.Segment(name))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1394,7 +1437,8 @@ func (c *Client) CameraReboot(ctx context.Context, name string) error {
 // DeleteOrganizationPreset Delete preset from organization
 // This method allows to remove a specific preset from organization.
 func (c *Client) DeleteOrganizationPreset(ctx context.Context, organization_id string, preset_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/presets/%s", organization_id, preset_id)
+	ctx = baseclient.WithOperation(ctx, "DeleteOrganizationPreset")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/presets/%s", urlpath.Segment(organization_id), urlpath.Segment(preset_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1404,7 +1448,8 @@ func (c *Client) DeleteOrganizationPreset(ctx context.Context, organization_id s
 // DeviceTokenConfirm Confirm notification received by device
 // Should be called by a device when notification received
 func (c *Client) DeviceTokenConfirm(ctx context.Context, token string) (model.DeviceToken, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s/confirm", token)
+	ctx = baseclient.WithOperation(ctx, "DeviceTokenConfirm")
+	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s/confirm", urlpath.Segment(token))
 	result := &model.DeviceTokenImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
 		return nil, err
@@ -1415,7 +1460,8 @@ func (c *Client) DeviceTokenConfirm(ctx context.Context, token string) (model.De
 // DeviceTokenDelete Delete device token
 // Delete device token. Mobile application have to delete all expired device tokens, received from Firebase.
 func (c *Client) DeviceTokenDelete(ctx context.Context, token string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", token)
+	ctx = baseclient.WithOperation(ctx, "DeviceTokenDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", urlpath.Segment(token))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1425,7 +1471,8 @@ func (c *Client) DeviceTokenDelete(ctx context.Context, token string) error {
 // DeviceTokenGet Get device token
 // Returns a device token info
 func (c *Client) DeviceTokenGet(ctx context.Context, token string) (model.DeviceToken, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", token)
+	ctx = baseclient.WithOperation(ctx, "DeviceTokenGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", urlpath.Segment(token))
 	result := &model.DeviceTokenImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1436,7 +1483,8 @@ func (c *Client) DeviceTokenGet(ctx context.Context, token string) (model.Device
 // DeviceTokenSave Save a new device token
 // Save a new device token in Watcher. You can read how to create it here: https://firebase.google.com/docs/cloud-messaging/android/client?hl=en#sample-register You must save and refresh token if you want to receive notifications about [camera events](#tag/profile/operation/event_subscription_create).
 func (c *Client) DeviceTokenSave(ctx context.Context, token string, body model.DeviceToken) (model.DeviceToken, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", token)
+	ctx = baseclient.WithOperation(ctx, "DeviceTokenSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/profile/device-tokens/%s", urlpath.Segment(token))
 	result := &model.DeviceTokenImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1447,7 +1495,8 @@ func (c *Client) DeviceTokenSave(ctx context.Context, token string, body model.D
 // EpisodeAddToFavorites Add episode to the favorites
 // This method allows you to add an episode to the favorites.
 func (c *Client) EpisodeAddToFavorites(ctx context.Context, episode_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s/favorites", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeAddToFavorites")
+	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s/favorites", urlpath.Segment(episode_id))
 	if err := c.doPost(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1457,7 +1506,11 @@ func (c *Client) EpisodeAddToFavorites(ctx context.Context, episode_id string) e
 // EpisodeDelete Delete episode
 // This method allows you to delete a registered episode. Only manually created episodes can be deleted. Episodes created automatically by the system (e.g., by video analytics) cannot be deleted.
 func (c *Client) EpisodeDelete(ctx context.Context, episode_id string, query *EpisodeDeleteQuery) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeDelete")
+	path, err := withQuery(fmt.Sprintf("/watcher/client-api/v3/episodes/%s", urlpath.Segment(episode_id)), query)
+	if err != nil {
+		return err
+	}
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1467,7 +1520,8 @@ func (c *Client) EpisodeDelete(ctx context.Context, episode_id string, query *Ep
 // EpisodeDeleteFromFavorites Delete episode from the favorites
 // This method allows you to delete an episode from the favorites.
 func (c *Client) EpisodeDeleteFromFavorites(ctx context.Context, episode_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s/favorites", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeDeleteFromFavorites")
+	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s/favorites", urlpath.Segment(episode_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1477,7 +1531,8 @@ func (c *Client) EpisodeDeleteFromFavorites(ctx context.Context, episode_id stri
 // EpisodeGet Get episode
 // This method allows you to fetch a registered episode
 func (c *Client) EpisodeGet(ctx context.Context, episode_id string, query *EpisodeGetQuery) (model.WatcherEpisode, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s", episode_id)
+	ctx = baseclient.WithOperation(ctx, "EpisodeGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/episodes/%s", urlpath.Segment(episode_id))
 	result := &model.WatcherEpisodeImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1488,6 +1543,7 @@ func (c *Client) EpisodeGet(ctx context.Context, episode_id string, query *Episo
 // EpisodesList List episodes
 // This method allows you to fetch registered episodes
 func (c *Client) EpisodesList(ctx context.Context, query *EpisodesListQuery) (model.EpisodesList, error) {
+	ctx = baseclient.WithOperation(ctx, "EpisodesList")
 	path := "/watcher/client-api/v3/episodes"
 	result := &model.EpisodesListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1504,6 +1560,7 @@ func (c *Client) EpisodesListIterator(ctx context.Context, query *EpisodesListQu
 // EventSubscriptionCreate Create a new event subscription on specific stream
 // Create a new event subscription
 func (c *Client) EventSubscriptionCreate(ctx context.Context, body model.SubscriptionRequest) (model.Subscription, error) {
+	ctx = baseclient.WithOperation(ctx, "EventSubscriptionCreate")
 	path := "/watcher/client-api/v3/profile/subscriptions/stream_event"
 	result := &model.SubscriptionImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1515,8 +1572,9 @@ func (c *Client) EventSubscriptionCreate(ctx context.Context, body model.Subscri
 // EventSubscriptionDelete Unsubscribe from events on specific stream
 // Delete subscription on events
 func (c *Client) EventSubscriptionDelete(ctx context.Context, body model.SubscriptionRequest) error {
+	ctx = baseclient.WithOperation(ctx, "EventSubscriptionDelete")
 	path := "/watcher/client-api/v3/profile/subscriptions/stream_event"
-	if err := c.doDelete(ctx, path); err != nil {
+	if err := c.doDeleteWithBody(ctx, path, body); err != nil {
 		return err
 	}
 	return nil
@@ -1525,7 +1583,8 @@ func (c *Client) EventSubscriptionDelete(ctx context.Context, body model.Subscri
 // FolderCreate Create folder
 // Create folder.
 func (c *Client) FolderCreate(ctx context.Context, organization_id string, body model.Folder) (model.Folder, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders", organization_id)
+	ctx = baseclient.WithOperation(ctx, "FolderCreate")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders", urlpath.Segment(organization_id))
 	result := &model.FolderImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1536,7 +1595,8 @@ func (c *Client) FolderCreate(ctx context.Context, organization_id string, body
 // FolderDelete Delete folder
 // This method allows to delete a folder.
 func (c *Client) FolderDelete(ctx context.Context, organization_id string, folder_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", organization_id, folder_id)
+	ctx = baseclient.WithOperation(ctx, "FolderDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1546,7 +1606,8 @@ func (c *Client) FolderDelete(ctx context.Context, organization_id string, folde
 // FolderGet Get folder info
 // This method allows to fetch folder info.
 func (c *Client) FolderGet(ctx context.Context, organization_id string, folder_id string) (model.Folder, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", organization_id, folder_id)
+	ctx = baseclient.WithOperation(ctx, "FolderGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id))
 	result := &model.FolderImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1557,7 +1618,8 @@ func (c *Client) FolderGet(ctx context.Context, organization_id string, folder_i
 // FolderList Get list of folders
 // This method allows to fetch a list of folders info.
 func (c *Client) FolderList(ctx context.Context, organization_id string, query *FolderListQuery) (model.FoldersList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders", organization_id)
+	ctx = baseclient.WithOperation(ctx, "FolderList")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders", urlpath.Segment(organization_id))
 	result := &model.FoldersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1575,7 +1637,8 @@ func (c *Client) FolderListIterator(ctx context.Context, organization_id string,
 // FolderSave Save folder
 // Save folder.
 func (c *Client) FolderSave(ctx context.Context, organization_id string, folder_id string, body model.Folder) (model.Folder, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", organization_id, folder_id)
+	ctx = baseclient.WithOperation(ctx, "FolderSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id))
 	result := &model.FolderImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1586,7 +1649,8 @@ func (c *Client) FolderSave(ctx context.Context, organization_id string, folder_
 // FolderUserDelete Delete user in a folder
 // This method allows to delete user info in a folder.
 func (c *Client) FolderUserDelete(ctx context.Context, organization_id string, folder_id string, user_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", organization_id, folder_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "FolderUserDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id), urlpath.Segment(user_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1596,7 +1660,8 @@ func (c *Client) FolderUserDelete(ctx context.Context, organization_id string, f
 // FolderUserGet Get user in a folder
 // This method allows to fetch user in a folder.
 func (c *Client) FolderUserGet(ctx context.Context, organization_id string, folder_id string, user_id string) (model.FolderUser, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", organization_id, folder_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "FolderUserGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id), urlpath.Segment(user_id))
 	result := &model.FolderUserImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1607,7 +1672,8 @@ func (c *Client) FolderUserGet(ctx context.Context, organization_id string, fold
 // FolderUserSave Save user in a folder
 // Save user info in a folder.
 func (c *Client) FolderUserSave(ctx context.Context, organization_id string, folder_id string, user_id string, body model.FolderUser) (model.FolderUser, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", organization_id, folder_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "FolderUserSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(folder_id), urlpath.Segment(user_id))
 	result := &model.FolderUserImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1618,7 +1684,8 @@ func (c *Client) FolderUserSave(ctx context.Context, organization_id string, fol
 // FolderUsersList Get a list of users in a folder
 // This method allows to fetch a list of users in a folder.
 func (c *Client) FolderUsersList(ctx context.Context, organization_id string, folder_id string, query *FolderUsersListQuery) (model.FolderUsersList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users", organization_id, folder_id)
+	ctx = baseclient.WithOperation(ctx, "FolderUsersList")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/folders/%s/users", urlpath.Segment(organization_id), urlpath.Segment(folder_id))
 	result := &model.FolderUsersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1636,7 +1703,8 @@ func (c *Client) FolderUsersListIterator(ctx context.Context, organization_id st
 // GetInviteShortInfo Get organization invite short info
 // This method allows to get short info about organization invite.
 func (c *Client) GetInviteShortInfo(ctx context.Context, invite_key string) (model.OrganizationBase, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/invite/%s", invite_key)
+	ctx = baseclient.WithOperation(ctx, "GetInviteShortInfo")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/invite/%s", urlpath.Segment(invite_key))
 	result := &model.OrganizationBaseImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1647,6 +1715,7 @@ func (c *Client) GetInviteShortInfo(ctx context.Context, invite_key string) (mod
 // LoginCreate Get an auth token
 // The method creates a new temporary auth-token (JWT). The JTW token used as Bearer token in requests other than `/login`.
 func (c *Client) LoginCreate(ctx context.Context) (model.LoginInfoAdditional, error) {
+	ctx = baseclient.WithOperation(ctx, "LoginCreate")
 	path := "/watcher/client-api/v3/login"
 	result := &model.LoginInfoAdditionalImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
@@ -1658,7 +1727,8 @@ func (c *Client) LoginCreate(ctx context.Context) (model.LoginInfoAdditional, er
 // MessageDelete Delete a message
 // This method allows you to delete a message
 func (c *Client) MessageDelete(ctx context.Context, message_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", message_id)
+	ctx = baseclient.WithOperation(ctx, "MessageDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", urlpath.Segment(message_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1668,7 +1738,8 @@ func (c *Client) MessageDelete(ctx context.Context, message_id string) error {
 // MessageGet Get a message
 // This method allows you to fetch the message by its identifier
 func (c *Client) MessageGet(ctx context.Context, message_id string) (model.Message, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", message_id)
+	ctx = baseclient.WithOperation(ctx, "MessageGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", urlpath.Segment(message_id))
 	result := &model.MessageImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1679,6 +1750,7 @@ func (c *Client) MessageGet(ctx context.Context, message_id string) (model.Messa
 // MessageSend Send a message
 // This method allows you to send a message to a user
 func (c *Client) MessageSend(ctx context.Context, body model.MessageSend) (model.MessageResponse, error) {
+	ctx = baseclient.WithOperation(ctx, "MessageSend")
 	path := "/watcher/client-api/v3/messages"
 	result := &model.MessageResponseImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1690,7 +1762,8 @@ func (c *Client) MessageSend(ctx context.Context, body model.MessageSend) (model
 // MessageUpdate Update message
 // Update message by its id.
 func (c *Client) MessageUpdate(ctx context.Context, message_id string, body model.MessageChangeRequest) (model.Message, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", message_id)
+	ctx = baseclient.WithOperation(ctx, "MessageUpdate")
+	path := fmt.Sprintf("/watcher/client-api/v3/messages/%s", urlpath.Segment(message_id))
 	result := &model.MessageImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1701,6 +1774,7 @@ func (c *Client) MessageUpdate(ctx context.Context, message_id string, body mode
 // MessagesList List of messages
 // List of messages
 func (c *Client) MessagesList(ctx context.Context, query *MessagesListQuery) (model.Messages, error) {
+	ctx = baseclient.WithOperation(ctx, "MessagesList")
 	path := "/watcher/client-api/v3/messages"
 	result := &model.MessagesImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1717,6 +1791,7 @@ func (c *Client) MessagesListIterator(ctx context.Context, query *MessagesListQu
 // MosaicCreate Create mosaic
 // This method allows you to create a mosaic
 func (c *Client) MosaicCreate(ctx context.Context, body any) (model.Mosaic, error) {
+	ctx = baseclient.WithOperation(ctx, "MosaicCreate")
 	path := "/watcher/client-api/v3/mosaics"
 	result := &model.MosaicImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1728,7 +1803,8 @@ func (c *Client) MosaicCreate(ctx context.Context, body any) (model.Mosaic, erro
 // MosaicDelete Delete a mosaic
 // This method allows you to delete a mosaic
 func (c *Client) MosaicDelete(ctx context.Context, mosaic_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", mosaic_id)
+	ctx = baseclient.WithOperation(ctx, "MosaicDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", urlpath.Segment(mosaic_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1738,7 +1814,8 @@ func (c *Client) MosaicDelete(ctx context.Context, mosaic_id string) error {
 // MosaicGet Get mosaic detail
 // This method is used to get info about mosaic by its id.
 func (c *Client) MosaicGet(ctx context.Context, mosaic_id string) (model.Mosaic, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", mosaic_id)
+	ctx = baseclient.WithOperation(ctx, "MosaicGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", urlpath.Segment(mosaic_id))
 	result := &model.MosaicImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1749,7 +1826,8 @@ func (c *Client) MosaicGet(ctx context.Context, mosaic_id string) (model.Mosaic,
 // MosaicSave Save mosaic
 // This method allows you to update mosaic data
 func (c *Client) MosaicSave(ctx context.Context, mosaic_id string, body model.MosaicSave) (model.Mosaic, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", mosaic_id)
+	ctx = baseclient.WithOperation(ctx, "MosaicSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/mosaics/%s", urlpath.Segment(mosaic_id))
 	result := &model.MosaicImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1760,6 +1838,7 @@ func (c *Client) MosaicSave(ctx context.Context, mosaic_id string, body model.Mo
 // MosaicsList List mosaics
 // Returns the list of all mosaics. Mosaics allow you to combine multiple cameras to display on a single screen.
 func (c *Client) MosaicsList(ctx context.Context, query *MosaicsListQuery) (model.MosaicsList, error) {
+	ctx = baseclient.WithOperation(ctx, "MosaicsList")
 	path := "/watcher/client-api/v3/mosaics"
 	result := &model.MosaicsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1776,6 +1855,7 @@ func (c *Client) MosaicsListIterator(ctx context.Context, query *MosaicsListQuer
 // NotificationSend Send an arbitrary push notification
 // Send an arbitrary push notification. This method sends test push notification to user.
 func (c *Client) NotificationSend(ctx context.Context, body any) error {
+	ctx = baseclient.WithOperation(ctx, "NotificationSend")
 	path := "/watcher/client-api/v3/notifications/notify"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1786,6 +1866,7 @@ func (c *Client) NotificationSend(ctx context.Context, body any) error {
 // OrganizationCreate Create organization
 // Create new organization
 func (c *Client) OrganizationCreate(ctx context.Context, body model.Organization) (model.Organization, error) {
+	ctx = baseclient.WithOperation(ctx, "OrganizationCreate")
 	path := "/watcher/client-api/v3/organizations"
 	result := &model.OrganizationImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -1797,7 +1878,8 @@ func (c *Client) OrganizationCreate(ctx context.Context, body model.Organization
 // OrganizationDelete Delete organization
 // Delete organization by its id.
 func (c *Client) OrganizationDelete(ctx context.Context, organization_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", urlpath.Segment(organization_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1807,7 +1889,8 @@ func (c *Client) OrganizationDelete(ctx context.Context, organization_id string)
 // OrganizationGet Get single organization info
 // This method allows to fetch a single organization info. Refer to the `organizations_list` operation to find out what data will be returned in this method.
 func (c *Client) OrganizationGet(ctx context.Context, organization_id string) (model.Organization, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", urlpath.Segment(organization_id))
 	result := &model.OrganizationImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1818,7 +1901,8 @@ func (c *Client) OrganizationGet(ctx context.Context, organization_id string) (m
 // OrganizationInviteAccept Accept organization invite
 // This method allows to accept organization invite and add user to organization.
 func (c *Client) OrganizationInviteAccept(ctx context.Context, invite_key string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/invite/%s", invite_key)
+	ctx = baseclient.WithOperation(ctx, "OrganizationInviteAccept")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/invite/%s", urlpath.Segment(invite_key))
 	if err := c.doPut(ctx, path, nil, nil); err != nil {
 		return err
 	}
@@ -1828,7 +1912,8 @@ func (c *Client) OrganizationInviteAccept(ctx context.Context, invite_key string
 // OrganizationInviteCreate Create organization invite key
 // This method allows to create a single organizations invite key.  Using this key user can invite other users to the organization.  Only organization owner can create invite key.
 func (c *Client) OrganizationInviteCreate(ctx context.Context, organization_id string, body model.OrganizationInviteSetup) (model.OrganizationInviteKey, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/invite", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationInviteCreate")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/invite", urlpath.Segment(organization_id))
 	result := &model.OrganizationInviteKeyImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1839,7 +1924,8 @@ func (c *Client) OrganizationInviteCreate(ctx context.Context, organization_id s
 // OrganizationPresetSave Update organizations preset
 // Save organization preset by its id or adds new preset to organization.
 func (c *Client) OrganizationPresetSave(ctx context.Context, organization_id string, body model.OrganizationPreset) (model.Preset, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/presets", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationPresetSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/presets", urlpath.Segment(organization_id))
 	result := &model.PresetImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1850,7 +1936,8 @@ func (c *Client) OrganizationPresetSave(ctx context.Context, organization_id str
 // OrganizationSave Update organization
 // Update organization by its id.
 func (c *Client) OrganizationSave(ctx context.Context, organization_id string, body model.Organization) (model.Organization, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s", urlpath.Segment(organization_id))
 	result := &model.OrganizationImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1861,7 +1948,8 @@ func (c *Client) OrganizationSave(ctx context.Context, organization_id string, b
 // OrganizationUserDelete Delete user from organization
 // Delete user from organization by its id.
 func (c *Client) OrganizationUserDelete(ctx context.Context, organization_id string, user_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", organization_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationUserDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(user_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1871,7 +1959,8 @@ func (c *Client) OrganizationUserDelete(ctx context.Context, organization_id str
 // OrganizationUserGet Get single organization user info
 // This method allows to fetch a single organizations user info.
 func (c *Client) OrganizationUserGet(ctx context.Context, organization_id string, user_id string) (model.OrganizationUser, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", organization_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationUserGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(user_id))
 	result := &model.OrganizationUserImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1882,7 +1971,8 @@ func (c *Client) OrganizationUserGet(ctx context.Context, organization_id string
 // OrganizationUserSave Update organizations user
 // Update organization user by its id or adds new user to organization.
 func (c *Client) OrganizationUserSave(ctx context.Context, organization_id string, user_id string, body model.OrganizationPermissions) (model.OrganizationUser, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", organization_id, user_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationUserSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users/%s", urlpath.Segment(organization_id), urlpath.Segment(user_id))
 	result := &model.OrganizationUserImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -1893,7 +1983,8 @@ func (c *Client) OrganizationUserSave(ctx context.Context, organization_id strin
 // OrganizationUsersList List users in organization
 // Users who are members of the organization
 func (c *Client) OrganizationUsersList(ctx context.Context, organization_id string, query *OrganizationUsersListQuery) (model.OrganizationUsersList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users", organization_id)
+	ctx = baseclient.WithOperation(ctx, "OrganizationUsersList")
+	path := fmt.Sprintf("/watcher/client-api/v3/organizations/%s/users", urlpath.Segment(organization_id))
 	result := &model.OrganizationUsersListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -1911,6 +2002,7 @@ func (c *Client) OrganizationUsersListIterator(ctx context.Context, organization
 // OrganizationsList List organizations
 // Organizations of which the user is member
 func (c *Client) OrganizationsList(ctx context.Context, query *OrganizationsListQuery) (model.OrganizationsList, error) {
+	ctx = baseclient.WithOperation(ctx, "OrganizationsList")
 	path := "/watcher/client-api/v3/organizations"
 	result := &model.OrganizationsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1927,6 +2019,7 @@ func (c *Client) OrganizationsListIterator(ctx context.Context, query *Organizat
 // PasswordRecovery Send password recovery email
 // Send user email with password recovery instructions. This method will return success  regardless of whether there is a user with the specified email address or not
 func (c *Client) PasswordRecovery(ctx context.Context, body any) error {
+	ctx = baseclient.WithOperation(ctx, "PasswordRecovery")
 	path := "/watcher/client-api/v3/password_recovery"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -1937,7 +2030,8 @@ func (c *Client) PasswordRecovery(ctx context.Context, body any) error {
 // PersonDelete Delete person
 // Delete person by its id.
 func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/persons/%s", urlpath.Segment(person_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -1947,7 +2041,8 @@ func (c *Client) PersonDelete(ctx context.Context, person_id string) error {
 // PersonGet Get the Person
 // This method allows you to fetch the person by its identifier
 func (c *Client) PersonGet(ctx context.Context, person_id string) (model.Person, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/persons/%s", person_id)
+	ctx = baseclient.WithOperation(ctx, "PersonGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/persons/%s", urlpath.Segment(person_id))
 	result := &model.PersonImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -1958,6 +2053,7 @@ func (c *Client) PersonGet(ctx context.Context, person_id string) (model.Person,
 // PersonListsGet Lists Person
 // This method allows you to fetch the person lists
 func (c *Client) PersonListsGet(ctx context.Context, query *PersonListsGetQuery) (model.PersonListsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PersonListsGet")
 	path := "/watcher/client-api/v3/person_lists"
 	result := &model.PersonListsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1974,6 +2070,7 @@ func (c *Client) PersonListsGetIterator(ctx context.Context, query *PersonListsG
 // PersonsList List Persons
 // The list of registered videoanalytics persons
 func (c *Client) PersonsList(ctx context.Context, query *PersonsListQuery) (model.PersonsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PersonsList")
 	path := "/watcher/client-api/v3/persons"
 	result := &model.PersonsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -1990,7 +2087,8 @@ func (c *Client) PersonsListIterator(ctx context.Context, query *PersonsListQuer
 // PresetGet Get one preset
 // This method is used to get info about preset by its id.
 func (c *Client) PresetGet(ctx context.Context, id string) (model.Preset, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/presets/%s", id)
+	ctx = baseclient.WithOperation(ctx, "PresetGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/presets/%s", urlpath.Segment(id))
 	result := &model.PresetImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2001,6 +2099,7 @@ func (c *Client) PresetGet(ctx context.Context, id string) (model.Preset, error)
 // PresetsList List presets
 // The presets in Watcher is a set of DVR and analytics parameters that you can use as a template when creating and configuring cameras. When you select a preset in the camera settings, the parameters from the preset are populated to the camera settings. A set of presets on the camera is defined by the set of presets selected for the camera's Organization. https://flussonic.com/doc/manage-presets-in-watcher-ui/  This method is used to get info about presets. If the `organization_id` is transferred this method will return presets only allowed in organization with that Id.
 func (c *Client) PresetsList(ctx context.Context, query *PresetsListQuery) (model.PresetsList, error) {
+	ctx = baseclient.WithOperation(ctx, "PresetsList")
 	path := "/watcher/client-api/v3/presets"
 	result := &model.PresetsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -2017,6 +2116,7 @@ func (c *Client) PresetsListIterator(ctx context.Context, query *PresetsListQuer
 // ProfileGet Get profile info
 // Get profile info.
 func (c *Client) ProfileGet(ctx context.Context) (model.Profile, error) {
+	ctx = baseclient.WithOperation(ctx, "ProfileGet")
 	path := "/watcher/client-api/v3/profile"
 	result := &model.ProfileImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -2028,6 +2128,7 @@ func (c *Client) ProfileGet(ctx context.Context) (model.Profile, error) {
 // ProfileSave Update profile info
 // Update profile info.
 func (c *Client) ProfileSave(ctx context.Context, body model.Profile) (model.Profile, error) {
+	ctx = baseclient.WithOperation(ctx, "ProfileSave")
 	path := "/watcher/client-api/v3/profile"
 	result := &model.ProfileImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
@@ -2039,6 +2140,7 @@ func (c *Client) ProfileSave(ctx context.Context, body model.Profile) (model.Pro
 // ResetPassword Reset password
 // Changes user password
 func (c *Client) ResetPassword(ctx context.Context, body model.PasswordReset) error {
+	ctx = baseclient.WithOperation(ctx, "ResetPassword")
 	path := "/watcher/client-api/v3/reset_password"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -2049,7 +2151,11 @@ func (c *Client) ResetPassword(ctx context.Context, body model.PasswordReset) er
 // StreamDelete Delete the stream
 // This method allows to delete a single stream. You can delete `config_override` by this API call if you want to remove all runtime overrides.  If you do not specify any `part` parameter, this stream will be deleted from `config_on_disk` and if it was `named_by: config`, then it will immediately stop.
 func (c *Client) StreamDelete(ctx context.Context, name string, query *StreamDeleteQuery) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamDelete")
+	path, err := withQuery(fmt.Sprintf("/watcher/client-api/v3/streams/%s", urlpath.Segment(name)), query)
+	if err != nil {
+		return err
+	}
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2059,7 +2165,8 @@ func (c *Client) StreamDelete(ctx context.Context, name string, query *StreamDel
 // StreamFirmwareUpdate Update camera firmware
 // This method allows you to update camera firmware
 func (c *Client) StreamFirmwareUpdate(ctx context.Context, name string, body model.FirmwareUpdate) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/firmware_update", name)
+	ctx = baseclient.WithOperation(ctx, "StreamFirmwareUpdate")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/firmware_update", urlpath.Segment(name))
 	if err := c.doPut(ctx, path, body, nil); err != nil {
 		return err
 	}
@@ -2069,7 +2176,8 @@ func (c *Client) StreamFirmwareUpdate(ctx context.Context, name string, body mod
 // StreamGet Get one stream
 // This method allows to fetch a single stream. Refer to the `streams_list` operation to find out what data will be returned in this method.
 func (c *Client) StreamGet(ctx context.Context, name string) (model.StreamConfig, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s", urlpath.Segment(name))
 	result := &model.StreamConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2080,7 +2188,8 @@ func (c *Client) StreamGet(ctx context.Context, name string) (model.StreamConfig
 // StreamPermissionUserDelete Delete stream permissions
 // Delete all permissions for a specific user to access a stream. Removes direct access permissions for a user to this stream. This API is accessible to: - Users with 'can_edit' permission for the stream - Organization admins with 'can_edit_streams' permission - Domain administrators - Organization owners
 func (c *Client) StreamPermissionUserDelete(ctx context.Context, name string, user_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", name, user_id)
+	ctx = baseclient.WithOperation(ctx, "StreamPermissionUserDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", urlpath.Segment(name), urlpath.Segment(user_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2090,7 +2199,8 @@ func (c *Client) StreamPermissionUserDelete(ctx context.Context, name string, us
 // StreamPermissionUserGet Get user permissions to a stream
 // Returns the permissions that a specific user has for a specified stream via stream permissions. Shows detailed permissions a user has for this stream. This API is accessible to: - Users with 'can_edit' permission for the stream - Organization admins with 'can_edit_streams' permission - Domain administrators - Organization owners
 func (c *Client) StreamPermissionUserGet(ctx context.Context, name string, user_id string) (model.StreamUserPermission, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", name, user_id)
+	ctx = baseclient.WithOperation(ctx, "StreamPermissionUserGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", urlpath.Segment(name), urlpath.Segment(user_id))
 	result := &model.StreamUserPermissionImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2101,7 +2211,8 @@ func (c *Client) StreamPermissionUserGet(ctx context.Context, name string, user_
 // StreamPermissionUserSave Update stream permissions
 // Saves permissions for a specific user to access a stream.  These permissions override folder-level permissions but are overridden by organization-level permissions. Updates or creates direct stream permissions for a user. These permissions override folder-level permissions but are overridden by organization-level permissions. This API is accessible to: - Users with 'can_edit' permission for the stream - Organization admins with 'can_edit_streams' permission - Domain administrators - Organization owners
 func (c *Client) StreamPermissionUserSave(ctx context.Context, name string, user_id string, body model.StreamPermissionsAccess) (model.StreamUserPermission, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", name, user_id)
+	ctx = baseclient.WithOperation(ctx, "StreamPermissionUserSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users/%s", urlpath.Segment(name), urlpath.Segment(user_id))
 	result := &model.StreamUserPermissionImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2112,7 +2223,8 @@ func (c *Client) StreamPermissionUserSave(ctx context.Context, name string, user
 // StreamPermissionsUsersList List users with permissions to a stream
 // Returns a list of users who have direct access to the specified stream via stream permissions. Lists all users with explicit permissions set for this stream. This API is accessible to: - Users with 'can_edit' permission for the stream - Organization admins with 'can_edit_streams' permission - Domain administrators - Organization owners
 func (c *Client) StreamPermissionsUsersList(ctx context.Context, name string, query *StreamPermissionsUsersListQuery) (model.StreamUsersPermissionsList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users", name)
+	ctx = baseclient.WithOperation(ctx, "StreamPermissionsUsersList")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/permissions/users", urlpath.Segment(name))
 	result := &model.StreamUsersPermissionsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2130,7 +2242,8 @@ func (c *Client) StreamPermissionsUsersListIterator(ctx context.Context, name st
 // StreamPtzExecute Execute a PTZ command
 // Execute a PTZ command
 func (c *Client) StreamPtzExecute(ctx context.Context, name string, body model.PtzCommand) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/ptz", name)
+	ctx = baseclient.WithOperation(ctx, "StreamPtzExecute")
+	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s/ptz", urlpath.Segment(name))
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
 	}
@@ -2140,7 +2253,11 @@ func (c *Client) StreamPtzExecute(ctx context.Context, name string, body model.P
 // StreamSave Save stream
 // Create or update a stream by its name. If the stream doesn't exists in the disk config, it will be created.  If you try to update a stream that is started from a template by a user request having `named_by=user`, a new stream will be created in the disk config.  If you pass only a partial stream configuration, this field will be updated, not the whole stream.  To create a new stream the property `name` is required.  Pass the `"$reset": true` option to replace the stream configuration with the provided one.
 func (c *Client) StreamSave(ctx context.Context, name string, query *StreamSaveQuery, body model.StreamConfig) (model.StreamConfig, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/streams/%s", name)
+	ctx = baseclient.WithOperation(ctx, "StreamSave")
+	path, err := withQuery(fmt.Sprintf("/watcher/client-api/v3/streams/%s", urlpath.Segment(name)), query)
+	if err != nil {
+		return nil, err
+	}
 	result := &model.StreamConfigImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2151,6 +2268,7 @@ func (c *Client) StreamSave(ctx context.Context, name string, query *StreamSaveQ
 // StreamsImport Import of streams
 // This method allows you to easily add or update camera configurations. If a camera with the specified name exists, its settings will be updated; otherwise, a new camera will be created. This is useful for making simultaneous changes to multiple cameras, such as integrating billing or setting up different user configurations.  New cameras will be added or existing ones updated without affecting others.  Requests can be sent in CSV format.  Ensure the user has permission to edit cameras in the relevant organizations before submitting.  If errors occur, no changes will be made, preserving the original configuration.
 func (c *Client) StreamsImport(ctx context.Context) (model.StreamsImportResponse, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamsImport")
 	path := "/watcher/client-api/v3/streams/import"
 	result := &model.StreamsImportResponseImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
@@ -2162,6 +2280,7 @@ func (c *Client) StreamsImport(ctx context.Context) (model.StreamsImportResponse
 // StreamsList List streams
 // This API method is one of the most important in whole API, because it gives the list of all streams.  `streams_list` in Watcher Admin API: * can list all streams within the Watcher. * admin is not allowed to watch content from any cameras for security reasons.  `streams_list` in Watcher Client API: * return streams from organizations where you're member * prodives a playback token that allows play video from the camera.
 func (c *Client) StreamsList(ctx context.Context, query *StreamsListQuery) (model.StreamsList, error) {
+	ctx = baseclient.WithOperation(ctx, "StreamsList")
 	path := "/watcher/client-api/v3/streams"
 	result := &model.StreamsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -2178,6 +2297,7 @@ func (c *Client) StreamsListIterator(ctx context.Context, query *StreamsListQuer
 // StreamsMultiedit Save streams settings
 // Create or update settings for each stream. This operation does everything or nothing. If at least one stream has an invalid configuration or can't be processed, the request will fail.
 func (c *Client) StreamsMultiedit(ctx context.Context, body model.StreamsMultieditConfig) error {
+	ctx = baseclient.WithOperation(ctx, "StreamsMultiedit")
 	path := "/watcher/client-api/v3/streams/multiedit"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -2188,6 +2308,7 @@ func (c *Client) StreamsMultiedit(ctx context.Context, body model.StreamsMultied
 // UiSettingsGet Get UI settings
 // The method allows you to fetch UI settings including branding colors, logos, etc. It can work without authorization for the login page to be loaded.
 func (c *Client) UiSettingsGet(ctx context.Context) (model.UiSettingsConfig, error) {
+	ctx = baseclient.WithOperation(ctx, "UiSettingsGet")
 	path := "/watcher/client-api/v3/ui_settings"
 	result := &model.UiSettingsConfigImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -2199,6 +2320,7 @@ func (c *Client) UiSettingsGet(ctx context.Context) (model.UiSettingsConfig, err
 // UserApikeyCreate Create new apikey for user
 // Create apikey for user
 func (c *Client) UserApikeyCreate(ctx context.Context) (model.Apikey, error) {
+	ctx = baseclient.WithOperation(ctx, "UserApikeyCreate")
 	path := "/watcher/client-api/v3/profile/apikey"
 	result := &model.ApikeyImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
@@ -2210,6 +2332,7 @@ func (c *Client) UserApikeyCreate(ctx context.Context) (model.Apikey, error) {
 // UserApikeyDelete Delete apikey for user
 // Delete apikey for user
 func (c *Client) UserApikeyDelete(ctx context.Context) error {
+	ctx = baseclient.WithOperation(ctx, "UserApikeyDelete")
 	path := "/watcher/client-api/v3/profile/apikey"
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
@@ -2220,6 +2343,7 @@ func (c *Client) UserApikeyDelete(ctx context.Context) error {
 // UserApikeyGet Get users apikey
 // Get users apikey
 func (c *Client) UserApikeyGet(ctx context.Context) (model.Apikey, error) {
+	ctx = baseclient.WithOperation(ctx, "UserApikeyGet")
 	path := "/watcher/client-api/v3/profile/apikey"
 	result := &model.ApikeyImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
@@ -2231,6 +2355,7 @@ func (c *Client) UserApikeyGet(ctx context.Context) (model.Apikey, error) {
 // UserCreate Create user
 // This method allows you to create a user
 func (c *Client) UserCreate(ctx context.Context, body any) (model.User, error) {
+	ctx = baseclient.WithOperation(ctx, "UserCreate")
 	path := "/watcher/client-api/v3/users"
 	result := &model.UserImpl{}
 	if err := c.doPost(ctx, path, body, result); err != nil {
@@ -2242,7 +2367,8 @@ func (c *Client) UserCreate(ctx context.Context, body any) (model.User, error) {
 // UserDelete Delete a user
 // This method allows you to delete a user
 func (c *Client) UserDelete(ctx context.Context, user_id string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", urlpath.Segment(user_id))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2252,7 +2378,8 @@ func (c *Client) UserDelete(ctx context.Context, user_id string) error {
 // UserGet Get a user
 // This method allows you to fetch the user by its identifier
 func (c *Client) UserGet(ctx context.Context, user_id string) (model.User, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", urlpath.Segment(user_id))
 	result := &model.UserImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2263,7 +2390,8 @@ func (c *Client) UserGet(ctx context.Context, user_id string) (model.User, error
 // UserOrganizationFoldersList Get user's folders in organization
 // Get list of folders available for user in specific organization
 func (c *Client) UserOrganizationFoldersList(ctx context.Context, user_id string, organization_id string, query *UserOrganizationFoldersListQuery) (model.UserFolders, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/organization/%s/folders", user_id, organization_id)
+	ctx = baseclient.WithOperation(ctx, "UserOrganizationFoldersList")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/organization/%s/folders", urlpath.Segment(user_id), urlpath.Segment(organization_id))
 	result := &model.UserFoldersImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2281,7 +2409,8 @@ func (c *Client) UserOrganizationFoldersListIterator(ctx context.Context, user_i
 // UserOrganizationsList Get user's organizations
 // Get list of organizations available for user
 func (c *Client) UserOrganizationsList(ctx context.Context, user_id string, query *UserOrganizationsListQuery) (model.OrganizationsList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/organization", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserOrganizationsList")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/organization", urlpath.Segment(user_id))
 	result := &model.OrganizationsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2299,7 +2428,8 @@ func (c *Client) UserOrganizationsListIterator(ctx context.Context, user_id stri
 // UserSave Save user
 // This method allows you to update user data
 func (c *Client) UserSave(ctx context.Context, user_id string, body model.User) (model.User, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s", urlpath.Segment(user_id))
 	result := &model.UserImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2310,7 +2440,8 @@ func (c *Client) UserSave(ctx context.Context, user_id string, body model.User)
 // UserStreamPermissionDelete Delete stream permissions
 // Delete all permissions for the user to access a stream. Removes direct permissions for a user to access a specific stream. Note that the user may still have access via organization or folder permissions. This API is accessible to: - Organization admins with 'can_edit_users' permission - Domain administrators - Organization owners
 func (c *Client) UserStreamPermissionDelete(ctx context.Context, user_id string, name string) error {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", user_id, name)
+	ctx = baseclient.WithOperation(ctx, "UserStreamPermissionDelete")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", urlpath.Segment(user_id), urlpath.Segment(name))
 	if err := c.doDelete(ctx, path); err != nil {
 		return err
 	}
@@ -2320,7 +2451,8 @@ func (c *Client) UserStreamPermissionDelete(ctx context.Context, user_id string,
 // UserStreamPermissionGet Get user permissions to a stream
 // Returns the permissions that the user has for a specified stream via stream permissions. Allows viewing user-specific stream permissions. This API is accessible to: - The user themselves - Organization admins with 'can_edit_users' permission - Domain administrators - Organization owners
 func (c *Client) UserStreamPermissionGet(ctx context.Context, user_id string, name string) (model.UserStreamPermission, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", user_id, name)
+	ctx = baseclient.WithOperation(ctx, "UserStreamPermissionGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", urlpath.Segment(user_id), urlpath.Segment(name))
 	result := &model.UserStreamPermissionImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2331,7 +2463,8 @@ func (c *Client) UserStreamPermissionGet(ctx context.Context, user_id string, na
 // UserStreamPermissionSave Update user's stream permissions
 // Save permissions for the user to access a stream. These permissions override folder-level permissions but are overridden by organization-level permissions. Updates or creates permissions for a user to access a specific stream. This API is accessible to: - Organization admins with 'can_edit_users' permission - Domain administrators - Organization owners
 func (c *Client) UserStreamPermissionSave(ctx context.Context, user_id string, name string, body model.StreamPermissionsAccess) (model.UserStreamPermission, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", user_id, name)
+	ctx = baseclient.WithOperation(ctx, "UserStreamPermissionSave")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams/%s", urlpath.Segment(user_id), urlpath.Segment(name))
 	result := &model.UserStreamPermissionImpl{}
 	if err := c.doPut(ctx, path, body, result); err != nil {
 		return nil, err
@@ -2342,7 +2475,8 @@ func (c *Client) UserStreamPermissionSave(ctx context.Context, user_id string, n
 // UserStreamsPermissionsList List streams accessible to a user
 // Returns a list of streams to which the specified user has direct access via stream permissions. Lists all streams where a user has explicit permissions set. This API is accessible to: - The user themselves - Organization admins with 'can_edit_users' permission - Domain administrators - Organization owners
 func (c *Client) UserStreamsPermissionsList(ctx context.Context, user_id string, query *UserStreamsPermissionsListQuery) (model.UserStreamsPermissionsList, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams", user_id)
+	ctx = baseclient.WithOperation(ctx, "UserStreamsPermissionsList")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/permissions/streams", urlpath.Segment(user_id))
 	result := &model.UserStreamsPermissionsListImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
 		return nil, err
@@ -2360,7 +2494,8 @@ func (c *Client) UserStreamsPermissionsListIterator(ctx context.Context, user_id
 // UsersApikeyCreate Create new API key for user
 // The API key is used for authentication in API requests, allowing access to protected resources without using a password.
 func (c *Client) UsersApikeyCreate(ctx context.Context, user_id string) (model.Apikey, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/apikey", user_id)
+	ctx = baseclient.WithOperation(ctx, "UsersApikeyCreate")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/apikey", urlpath.Segment(user_id))
 	result := &model.ApikeyImpl{}
 	if err := c.doPost(ctx, path, nil, result); err != nil {
 		return nil, err
@@ -2371,7 +2506,8 @@ func (c *Client) UsersApikeyCreate(ctx context.Context, user_id string) (model.A
 // UsersApikeyGet Get user's API key
 // The API key is used for authentication in API requests, allowing access to protected resources without using a password.
 func (c *Client) UsersApikeyGet(ctx context.Context, user_id string) (model.Apikey, error) {
-	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/apikey", user_id)
+	ctx = baseclient.WithOperation(ctx, "UsersApikeyGet")
+	path := fmt.Sprintf("/watcher/client-api/v3/users/%s/apikey", urlpath.Segment(user_id))
 	result := &model.ApikeyImpl{}
 	if err := c.doGet(ctx, path, result); err != nil {
 		return nil, err
@@ -2382,6 +2518,7 @@ func (c *Client) UsersApikeyGet(ctx context.Context, user_id string) (model.Apik
 // UsersList List of users
 // List of available users
 func (c *Client) UsersList(ctx context.Context, query *UsersListQuery) (model.Users, error) {
+	ctx = baseclient.WithOperation(ctx, "UsersList")
 	path := "/watcher/client-api/v3/users"
 	result := &model.UsersImpl{}
 	if err := c.doList(ctx, path, query, result); err != nil {
@@ -2398,6 +2535,7 @@ func (c *Client) UsersListIterator(ctx context.Context, query *UsersListQuery) i
 // WebPushSubscribe Create subscription
 // Subscribes the client to Push Messages
 func (c *Client) WebPushSubscribe(ctx context.Context, body model.WebPushSubscriptionCreate) error {
+	ctx = baseclient.WithOperation(ctx, "WebPushSubscribe")
 	path := "/watcher/client-api/v3/web_push/subscribe"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -2408,9 +2546,10 @@ func (c *Client) WebPushSubscribe(ctx context.Context, body model.WebPushSubscri
 // WebPushSubscriptionExistenceGet Subscription existence
 // Checks for subscription on the backend
 func (c *Client) WebPushSubscriptionExistenceGet(ctx context.Context, query *WebPushSubscriptionExistenceGetQuery) (any, error) {
+	ctx = baseclient.WithOperation(ctx, "WebPushSubscriptionExistenceGet")
 	path := "/watcher/client-api/v3/web_push/subscription"
 	var result any
-	if err := c.doList(ctx, path, query, nil); err != nil {
+	if err := c.doList(ctx, path, query, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -2419,6 +2558,7 @@ func (c *Client) WebPushSubscriptionExistenceGet(ctx context.Context, query *Web
 // WebPushUnsubscribe Delete subscription
 // Deletes the subscription, so the client will no longer receive Push Messages on it
 func (c *Client) WebPushUnsubscribe(ctx context.Context, body model.WebPushSubscription) error {
+	ctx = baseclient.WithOperation(ctx, "WebPushUnsubscribe")
 	path := "/watcher/client-api/v3/web_push/unsubscribe"
 	if err := c.doPost(ctx, path, body, nil); err != nil {
 		return err
@@ -161,12 +161,12 @@ func TestClient_CameraAuthSave(t *testing.T) {
 	client := createTestClient(t, rt)
 
 	// Call method
-	_, err := client.CameraAuthSave(ctx, "test-name")
+	_, err := client.CameraAuthSave(ctx, "test-name", &model.AuthImpl{})
 	if err != nil {
 		t.Fatalf("CameraAuthSave failed: %v", err)
 	}
 	// Re-call to get result for verification
-	result, err := client.CameraAuthSave(ctx, "test-name")
+	result, err := client.CameraAuthSave(ctx, "test-name", &model.AuthImpl{})
 	if err != nil {
 		t.Fatalf("CameraAuthSave failed: %v", err)
 	}
@@ -3578,6 +3578,218 @@ func TestClient_WebPushUnsubscribe(t *testing.T) {
 	}
 }
 
+func TestClient_EpisodeDeleteSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &watcherclient.EpisodeDeleteQuery{Token: "episode-token"}
+	if err := client.EpisodeDelete(ctx, "42", query); err != nil {
+		t.Fatalf("EpisodeDelete failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("token"); got != "episode-token" {
+		t.Errorf("Expected query token=episode-token, got %q", got)
+	}
+}
+
+func TestClient_StreamSaveSendsQuery(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	query := &watcherclient.StreamSaveQuery{Part: "config_override"}
+	if _, err := client.StreamSave(ctx, "test-name", query, &model.StreamConfigImpl{}); err != nil {
+		t.Fatalf("StreamSave failed: %v", err)
+	}
+	if got := rt.request.URL.Query().Get("part"); got != "config_override" {
+		t.Errorf("Expected query part=config_override, got %q", got)
+	}
+}
+
+func TestClient_EventSubscriptionDeleteSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	body := model.NewSubscriptionRequest()
+	body.SetStreamName("test-stream")
+	if err := client.EventSubscriptionDelete(ctx, body); err != nil {
+		t.Fatalf("EventSubscriptionDelete failed: %v", err)
+	}
+	if rt.request.Method != http.MethodDelete {
+		t.Errorf("Expected method DELETE, got %s", rt.request.Method)
+	}
+	verifyRequestBody(t, rt.body, `{"stream_name":"test-stream"}`)
+}
+
+func TestClient_CameraAuthSaveSendsBody(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{}`}
+	client := createTestClient(t, rt)
+
+	body := model.NewAuth()
+	body.SetLogin("admin")
+	if _, err := client.CameraAuthSave(ctx, "test-name", body); err != nil {
+		t.Fatalf("CameraAuthSave failed: %v", err)
+	}
+	verifyRequestBody(t, rt.body, `{"login":"admin"}`)
+}
+
+func TestClient_StreamGetEscapesName(t *testing.T) {
+	ctx := context.Background()
+	for name, expected := range map[string]string{
+		"mylive/bunny":      "/watcher/client-api/v3/streams/mylive%2Fbunny",
+		"my stream":         "/watcher/client-api/v3/streams/my%20stream",
+		"камера":            "/watcher/client-api/v3/streams/%D0%BA%D0%B0%D0%BC%D0%B5%D1%80%D0%B0",
+		"../../config":      "/watcher/client-api/v3/streams/..%2F..%2Fconfig",
+		"..":                "/watcher/client-api/v3/streams/%2E%2E",
+		"name?format=csv#x": "/watcher/client-api/v3/streams/name%3Fformat=csv%23x",
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if _, err := client.StreamGet(ctx, name); err != nil {
+			t.Fatalf("StreamGet(%q) failed: %v", name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != expected {
+			t.Errorf("StreamGet(%q): expected path %s, got %s", name, expected, got)
+		}
+		if rt.request.URL.RawQuery != "" {
+			t.Errorf("StreamGet(%q): unexpected query %q", name, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestClient_QueryMethodsEscapeName(t *testing.T) {
+	ctx := context.Background()
+	name := "../live/cam?x=1#y"
+	for _, tt := range []struct {
+		method string
+		path   string
+		query  string
+		call   func(client watcherclient.WatcherClient) error
+	}{
+		{
+			"EpisodeDelete",
+			"/watcher/client-api/v3/episodes/..%2Flive%2Fcam%3Fx=1%23y",
+			"token=abc",
+			func(client watcherclient.WatcherClient) error {
+				return client.EpisodeDelete(ctx, name, &watcherclient.EpisodeDeleteQuery{Token: "abc"})
+			},
+		},
+		{
+			"StreamDelete",
+			"/watcher/client-api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y",
+			"part=dvr",
+			func(client watcherclient.WatcherClient) error {
+				return client.StreamDelete(ctx, name, &watcherclient.StreamDeleteQuery{Part: "dvr"})
+			},
+		},
+		{
+			"StreamSave",
+			"/watcher/client-api/v3/streams/..%2Flive%2Fcam%3Fx=1%23y",
+			"part=dvr",
+			func(client watcherclient.WatcherClient) error {
+				_, err := client.StreamSave(ctx, name, &watcherclient.StreamSaveQuery{Part: "dvr"}, &model.StreamConfigImpl{})
+				return err
+			},
+		},
+	} {
+		rt := &capturingRoundTripper{responseJSON: `{}`}
+		client := createTestClient(t, rt)
+
+		if err := tt.call(client); err != nil {
+			t.Fatalf("%s(%q) failed: %v", tt.method, name, err)
+		}
+		if got := rt.request.URL.EscapedPath(); got != tt.path {
+			t.Errorf("%s(%q): expected path %s, got %s", tt.method, name, tt.path, got)
+		}
+		if rt.request.URL.RawQuery != tt.query {
+			t.Errorf("%s(%q): expected query %q, got %q", tt.method, name, tt.query, rt.request.URL.RawQuery)
+		}
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"access_token":"session","refresh_token":"refresh"}`}
+	auth := watcherclient.LoginAuth(createTestClient(t, rt))
+
+	header, err := auth.HeaderContext(ctx)
+	if err != nil {
+		t.Fatalf("HeaderContext failed: %v", err)
+	}
+	if header != "Bearer session" {
+		t.Errorf("Expected header Bearer session, got %s", header)
+	}
+	if rt.request.Method != http.MethodPost || rt.request.URL.Path != "/watcher/client-api/v3/login" {
+		t.Errorf("Unexpected login request %s %s", rt.request.Method, rt.request.URL.Path)
+	}
+}
+
+func TestApikeyAuth(t *testing.T) {
+	ctx := context.Background()
+	rt := &capturingRoundTripper{responseJSON: `{"apikey":"key"}`}
+	auth := watcherclient.ApikeyAuth(createTestClient(t, rt))
+
+	header, err := auth.HeaderContext(ctx)
+	if err != nil {
+		t.Fatalf("HeaderContext failed: %v", err)
+	}
+	if header != "Bearer key" {
+		t.Errorf("Expected header Bearer key, got %s", header)
+	}
+	if rt.request.Method != http.MethodPost || rt.request.URL.Path != "/watcher/client-api/v3/profile/apikey" {
+		t.Errorf("Unexpected apikey request %s %s", rt.request.Method, rt.request.URL.Path)
+	}
+
+	rt.responseJSON = `{}`
+	if _, err := watcherclient.ApikeyAuth(createTestClient(t, rt)).HeaderContext(ctx); err == nil {
+		t.Error("Expected error for response without apikey")
+	}
+}
+
+// capturingRoundTripper records the last request and returns example response
+type capturingRoundTripper struct {
+	request      *http.Request
+	body         []byte
+	responseJSON string
+}
+
+func (rt *capturingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.request = req
+	if req.Body != nil {
+		rt.body, _ = io.ReadAll(req.Body)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader([]byte(rt.responseJSON))),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	return resp, nil
+}
+
+// verifyRequestBody checks that every field of expectedJSON is present in the sent body
+func verifyRequestBody(t *testing.T, body []byte, expectedJSON string) {
+	var expected, actual map[string]any
+	if err := json.Unmarshal([]byte(expectedJSON), &expected); err != nil {
+		t.Fatalf("Failed to unmarshal expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(body, &actual); err != nil {
+		t.Fatalf("Failed to unmarshal request body %q: %v", string(body), err)
+	}
+	for key, value := range expected {
+		expectedValue, _ := json.Marshal(value)
+		actualValue, _ := json.Marshal(actual[key])
+		if !bytes.Equal(expectedValue, actualValue) {
+			t.Errorf("Body key %s: expected %s, got %s", key, expectedValue, actualValue)
+		}
+	}
+}
+
 type validatingRoundTripper struct {
 	t              *testing.T
 	expectedMethod string